@@ -0,0 +1,164 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/dumbogo/chess/engine"
+)
+
+// newTimeControl validates a time control, setting it on gameDb along with the players time
+func newTimeControl(gameDb *Game, timeControl *TimeControl) error {
+	base := time.Duration(timeControl.GetBaseSeconds()) * time.Second
+	increment := time.Duration(timeControl.GetIncrementSeconds()) * time.Second
+	days := int(timeControl.GetDaysPerMove())
+	switch {
+	case days > 0 && (base > 0 || increment > 0):
+		return errors.New("time control must be either base time plus increment or days per move")
+	case increment > 0 && base == 0:
+		return errors.New("time control increment requires a base time")
+	}
+	gameDb.BaseTime, gameDb.Increment, gameDb.DaysPerMove = base, increment, days
+	if days > 0 {
+		base = time.Duration(days) * 24 * time.Hour
+	}
+	gameDb.WhiteTime, gameDb.BlackTime = base, base
+	return nil
+}
+
+// timed returns true if the game is played with clocks
+func (g *Game) timed() bool {
+	return g.BaseTime > 0 || g.DaysPerMove > 0
+}
+
+// turnColor returns the color of the player in turn
+func (g *Game) turnColor() engine.Color {
+	if g.BlackPlayerID.Valid && g.Turn == uint(g.BlackPlayerID.Int32) {
+		return engine.BlackColor
+	}
+	return engine.WhiteColor
+}
+
+// startClock starts the clock of the player in turn, once both players joined
+func (g *Game) startClock(now time.Time) {
+	if g.timed() {
+		g.TurnStartedAt.Time, g.TurnStartedAt.Valid = now, true
+	}
+}
+
+// timeLeft returns the time left of each player at now, the clock of the player in turn
+// runs since the turn started and stops once the game finished
+func (g *Game) timeLeft(now time.Time) (white, black time.Duration) {
+	white, black = g.WhiteTime, g.BlackTime
+	if !g.timed() || !g.TurnStartedAt.Valid || g.Winner != 0 {
+		return white, black
+	}
+	elapsed := now.Sub(g.TurnStartedAt.Time)
+	if g.turnColor() == engine.WhiteColor {
+		white -= elapsed
+	} else {
+		black -= elapsed
+	}
+	if white < 0 {
+		white = 0
+	}
+	if black < 0 {
+		black = 0
+	}
+	return white, black
+}
+
+// flagFallen returns true if the time of the player in turn ran out at now
+func (g *Game) flagFallen(now time.Time) bool {
+	if !g.timed() || !g.TurnStartedAt.Valid || g.Winner != 0 {
+		return false
+	}
+	white, black := g.timeLeft(now)
+	return white == 0 || black == 0
+}
+
+// pressClock stops the clock of the player in turn after its movement, adding the increment,
+// or giving the days per move again on correspondence games, and starts the oponent clock
+func (g *Game) pressClock(now time.Time) {
+	if !g.timed() || !g.TurnStartedAt.Valid {
+		return
+	}
+	white, black := g.timeLeft(now)
+	left := &white
+	if g.turnColor() == engine.BlackColor {
+		left = &black
+	}
+	*left += g.Increment
+	if g.DaysPerMove > 0 {
+		*left = time.Duration(g.DaysPerMove) * 24 * time.Hour
+	}
+	g.WhiteTime, g.BlackTime = white, black
+	g.TurnStartedAt.Time = now
+}
+
+// restartClock deducts the time used by the player in turn, starting its clock again at now,
+// i.e. before the turn is given back by a takeback
+func (g *Game) restartClock(now time.Time) {
+	if !g.timed() || !g.TurnStartedAt.Valid {
+		return
+	}
+	g.WhiteTime, g.BlackTime = g.timeLeft(now)
+	g.TurnStartedAt.Time = now
+}
+
+// finishOnTimeout finishes the game when the time of the player in turn ran out at now, the oponent wins.
+// Returns true if the game finished, watchers are notified once
+func finishOnTimeout(gameDb *Game, gameEngine engine.Game, now time.Time) (bool, error) {
+	if !gameDb.flagFallen(now) {
+		return false, nil
+	}
+	if _, err := finishGame(gameDb, gameEngine, oponentWins(gameDb.turnColor()), engine.TimeoutReason, now); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// oponentWins returns the result of the game won by the oponent of color
+func oponentWins(color engine.Color) engine.Result {
+	if color == engine.WhiteColor {
+		return engine.BlackWinsResult
+	}
+	return engine.WhiteWinsResult
+}
+
+// finishGame finishes the game with result by reason at now, unless it changed since gameDb was loaded,
+// so a game finished on several replicas at once is only finished, and its watchers notified, once.
+// Returns true if this call finished the game
+func finishGame(gameDb *Game, gameEngine engine.Game, result engine.Result, reason engine.Reason, now time.Time) (bool, error) {
+	if err := gameEngine.Finish(result, reason); err != nil {
+		return false, err
+	}
+	whiteTime, blackTime := gameDb.timeLeft(now)
+	tx := DBConn.Table("games").
+		Where("id = ? AND winner = 0 AND updated_at = ?", gameDb.ID, gameDb.UpdatedAt).
+		Updates(map[string]interface{}{
+			"winner":     int(result),
+			"reason":     int(reason),
+			"white_time": whiteTime,
+			"black_time": blackTime,
+			"updated_at": now,
+			// requests to the oponent are no longer pending
+			"draw_offered_by":       nil,
+			"takeback_requested_by": nil,
+		})
+	if tx.Error != nil {
+		return false, tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		// finished or moved meanwhile, keeps the result saved
+		return false, DBConn.Select("winner", "reason").Where("id = ?", gameDb.ID).Take(gameDb).Error
+	}
+	gameDb.WhiteTime, gameDb.BlackTime = whiteTime, blackTime
+	gameDb.Winner, gameDb.Reason = int(result), int(reason)
+	gameDb.DrawOfferedBy, gameDb.TakebackRequestedBy = sql.NullInt32{}, sql.NullInt32{}
+	gameDb.UpdatedAt = now
+	gameDb.FEN = gameEngine.FEN()
+	gameDb.Moves = sanMoves(gameEngine)
+	return true, gameDb.AfterSave(DBConn)
+}
@@ -0,0 +1,63 @@
+// +build integration
+
+package api
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGameClock(t *testing.T) {
+	assert := assert.New(t)
+	now := time.Now()
+	g := &Game{
+		WhitePlayerID: sql.NullInt32{Int32: 1, Valid: true},
+		BlackPlayerID: sql.NullInt32{Int32: 2, Valid: true},
+		Turn:          1,
+	}
+	assert.Nil(newTimeControl(g, &TimeControl{BaseSeconds: 60, IncrementSeconds: 2}))
+	assert.True(g.timed())
+	assert.Equal(time.Minute, g.WhiteTime)
+	assert.Equal(time.Minute, g.BlackTime)
+
+	// clock does not run until started
+	white, _ := g.timeLeft(now.Add(time.Hour))
+	assert.Equal(time.Minute, white)
+
+	g.startClock(now)
+	white, black := g.timeLeft(now.Add(10 * time.Second))
+	assert.Equal(50*time.Second, white)
+	assert.Equal(time.Minute, black)
+
+	g.pressClock(now.Add(10 * time.Second))
+	g.Turn = 2
+	assert.Equal(52*time.Second, g.WhiteTime)
+	_, black = g.timeLeft(now.Add(40 * time.Second))
+	assert.Equal(30*time.Second, black)
+	assert.False(g.flagFallen(now.Add(69 * time.Second)))
+	assert.True(g.flagFallen(now.Add(70 * time.Second)))
+	_, black = g.timeLeft(now.Add(time.Hour))
+	assert.Equal(time.Duration(0), black)
+
+	// Case: correspondence games give the days per move again
+	g = &Game{WhitePlayerID: sql.NullInt32{Int32: 1, Valid: true}, Turn: 1}
+	assert.Nil(newTimeControl(g, &TimeControl{DaysPerMove: 3}))
+	assert.Equal(72*time.Hour, g.WhiteTime)
+	g.startClock(now)
+	g.pressClock(now.Add(48 * time.Hour))
+	assert.Equal(72*time.Hour, g.WhiteTime)
+
+	// Case: untimed games
+	g = &Game{}
+	assert.Nil(newTimeControl(g, &TimeControl{}))
+	assert.False(g.timed())
+	g.startClock(now)
+	assert.False(g.TurnStartedAt.Valid)
+	assert.False(g.flagFallen(now.Add(time.Hour)))
+
+	assert.EqualError(newTimeControl(&Game{}, &TimeControl{BaseSeconds: 60, DaysPerMove: 1}), "time control must be either base time plus increment or days per move")
+	assert.EqualError(newTimeControl(&Game{}, &TimeControl{IncrementSeconds: 2}), "time control increment requires a base time")
+}
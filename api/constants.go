@@ -1,8 +1,33 @@
 package api
 
+import "time"
+
 // ENV values accepted
 var (
 	EnvProduction = "production"
 	EnvTest       = "test"
 	EnvDev        = "development"
 )
+
+// Computer opponent searches
+const (
+	// computerHashSize transposition table size in megabytes of each computer search
+	computerHashSize = 4
+	// computerRetryAfter time the computer turn waits, i.e. after a failed search or a restart, before the sweeper plays it
+	computerRetryAfter = time.Minute
+	// maxComputerFailures failed movements in a row after which the computer loses the game by abandonment
+	maxComputerFailures = 3
+)
+
+// Board diagram sizes accepted, in pixels. Diagrams are served without authentication and
+// rendering costs grow with the square of the size, so it is kept small
+const (
+	minDiagramSize = 64
+	maxDiagramSize = 512
+)
+
+// diagramContentTypes content types of the board diagram formats
+var diagramContentTypes = map[string]string{
+	"svg": "image/svg+xml",
+	"png": "image/png",
+}
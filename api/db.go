@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"log"
 	"strconv"
+	"time"
 
 	"github.com/dumbogo/chess/engine"
 	"github.com/dumbogo/chess/messagebroker"
@@ -87,12 +88,38 @@ type Game struct {
 	BlackPlayer   Player `gorm:"foreignKey:BlackPlayerID"`
 	BlackPlayerID sql.NullInt32
 
-	Turn         uint
+	Turn uint
+	// Winner engine.Result when the game finished, zero while being played
 	Winner       int
 	Movements    []Movement // TODO: this will cause problems, implement when its needed by the engine
 	WhitePieces  pieces     `gorm:"type:jsonb;not null"`
 	BlackPieces  pieces     `gorm:"type:jsonb;not null"`
 	BoardSquares Squares    `gorm:"type:jsonb;not null"`
+	// Reason engine.Reason the game finished by, zero while being played
+	Reason int
+	// BaseTime and Increment time control, or DaysPerMove for correspondence games, the game is not timed when zero
+	BaseTime    time.Duration
+	Increment   time.Duration
+	DaysPerMove int
+	// WhiteTime and BlackTime time left of each player when the turn started
+	WhiteTime time.Duration
+	BlackTime time.Duration
+	// TurnStartedAt when the clock of the player in turn started, null until both players joined
+	TurnStartedAt sql.NullTime
+	// DrawOfferedBy player offering a draw to its oponent, null when none
+	DrawOfferedBy sql.NullInt32
+	// TakebackRequestedBy player asking its oponent to take back its last movement, null when none
+	TakebackRequestedBy sql.NullInt32
+	// ComputerFailures failed movements in a row of the computer player in turn, see maxComputerFailures
+	ComputerFailures int
+	// Hash Zobrist hash of the current position, see engine.Game Hash
+	Hash int64 `gorm:"index"`
+	// LastMove SAN of the movement being saved, sent to watchers
+	LastMove string `gorm:"-"`
+	// FEN position after the movement being saved, sent to watchers
+	FEN string `gorm:"-"`
+	// Moves SAN of every movement made, sent to watchers
+	Moves []string `gorm:"-"`
 }
 
 // AfterSave ...
@@ -101,16 +128,26 @@ func (g *Game) AfterSave(tx *gorm.DB) (err error) {
 		return nil
 	}
 
-	turnColor := "white"
-	if g.Turn == uint(g.BlackPlayerID.Int32) {
-		turnColor = "black"
-	}
-
 	board := engine.LoadBoard(&engine.Player{}, &engine.Player{}, squaresToEngineSquares(g.BoardSquares))
+	whiteTime, blackTime := g.timeLeft(time.Now())
+	drawOffer, drawOfferColor := g.requestColor(g.DrawOfferedBy)
+	takebackRequest, takebackRequestColor := g.requestColor(g.TakebackRequestedBy)
 	bytes, err := json.Marshal(payloadUpdateGame{
-		Turn:   fmt.Sprint(g.Turn), // TODO: add corresponding color player
-		Status: fmt.Sprintf("%s turn", turnColor),
-		Board:  board.String(),
+		Turn:      fmt.Sprint(g.Turn), // TODO: add corresponding color player
+		Status:    g.status(),
+		Board:     board.String(),
+		LastMove:  g.LastMove,
+		FEN:       g.FEN,
+		Moves:     g.Moves,
+		Timed:     g.timed(),
+		WhiteTime: whiteTime.Milliseconds(),
+		BlackTime: blackTime.Milliseconds(),
+
+		DrawOffer:      drawOffer,
+		DrawOfferColor: drawOfferColor,
+
+		TakebackRequest:      takebackRequest,
+		TakebackRequestColor: takebackRequestColor,
 	})
 	if err != nil {
 		return err
@@ -119,10 +156,56 @@ func (g *Game) AfterSave(tx *gorm.DB) (err error) {
 	return
 }
 
+// status returns the player in turn, or the result once the game finished
+func (g *Game) status() string {
+	if g.Winner == 0 {
+		if g.turnColor() == engine.BlackColor {
+			return "black turn"
+		}
+		return "white turn"
+	}
+	if g.Reason == 0 {
+		return fmt.Sprintf("game finished, %s", engine.Result(g.Winner))
+	}
+	return fmt.Sprintf("game finished, %s", engine.Status{Result: engine.Result(g.Winner), Reason: engine.Reason(g.Reason)})
+}
+
+// playerColor returns the color player plays with
+func (g *Game) playerColor(player Player) engine.Color {
+	if g.BlackPlayerID.Valid && uint(g.BlackPlayerID.Int32) == player.ID {
+		return engine.BlackColor
+	}
+	return engine.WhiteColor
+}
+
+// requestColor returns true and the color of the player making a request to its oponent, i.e. a draw offer, if any
+func (g *Game) requestColor(playerID sql.NullInt32) (bool, Color) {
+	if !playerID.Valid {
+		return false, Color_WHITE
+	}
+	if playerID == g.BlackPlayerID {
+		return true, Color_BLACK
+	}
+	return true, Color_WHITE
+}
+
 type payloadUpdateGame struct {
-	Turn   string `json:"turn"`
-	Board  string `json:"board"`
-	Status string `json:"status"`
+	Turn     string   `json:"turn"`
+	Board    string   `json:"board"`
+	Status   string   `json:"status"`
+	LastMove string   `json:"last_move"`
+	FEN      string   `json:"fen"`
+	Moves    []string `json:"moves"`
+	Timed    bool     `json:"timed"`
+	// WhiteTime and BlackTime time left in milliseconds
+	WhiteTime int64 `json:"white_time"`
+	BlackTime int64 `json:"black_time"`
+	// DrawOffer true while the player of DrawOfferColor offers a draw
+	DrawOffer      bool  `json:"draw_offer"`
+	DrawOfferColor Color `json:"draw_offer_color"`
+	// TakebackRequest true while the player of TakebackRequestColor asks to take back its last movement
+	TakebackRequest      bool  `json:"takeback_request"`
+	TakebackRequestColor Color `json:"takeback_request_color"`
 }
 
 type pieces map[uint8]uint8
@@ -239,6 +322,9 @@ type Player struct {
 	Color  string
 	User   User
 	UserID uint
+	// Level computer strength when the server plays for this player, 0 for human players.
+	// Computer players belong to the user playing against them
+	Level int
 }
 
 // Movement Model
@@ -248,8 +334,72 @@ type Movement struct {
 	PieceEaten int
 	From       string
 	To         string
-	Player     Player
-	PlayerID   uint
-	Game       Game
-	GameID     uint
+	// EnPassant PieceEaten was a pawn taken en passant
+	EnPassant bool
+	// Castling king castled, rook moved from RookFrom to RookTo
+	Castling  bool
+	RookFrom  string
+	RookTo    string
+	Promotion int
+	SAN       string
+	Player    Player
+	PlayerID  uint
+	Game      Game
+	GameID    uint
+}
+
+// newMovement returns Movement Model from an engine movement made by player in game
+func newMovement(m engine.Movement, gameID, playerID uint) Movement {
+	movement := Movement{
+		PieceMoved: int(m.PieceMoved.Identifier()),
+		From:       engine.SquareIdentifierToString(m.From),
+		To:         engine.SquareIdentifierToString(m.To),
+		EnPassant:  m.EnPassant,
+		Castling:   m.Castling,
+		SAN:        m.SAN,
+		PlayerID:   playerID,
+		GameID:     gameID,
+	}
+	if m.PieceEaten != nil {
+		movement.PieceEaten = int(m.PieceEaten.Identifier())
+	}
+	if m.Castling {
+		movement.RookFrom = engine.SquareIdentifierToString(m.RookFrom)
+		movement.RookTo = engine.SquareIdentifierToString(m.RookTo)
+	}
+	if m.Promotion != nil {
+		movement.Promotion = int(m.Promotion.Identifier())
+	}
+	return movement
+}
+
+// movementsToEngineMovements returns game movements in the order they were made
+func movementsToEngineMovements(gameDb Game, movements []Movement) []engine.Movement {
+	engineMovements := make([]engine.Movement, 0, len(movements))
+	for _, v := range movements {
+		player := engine.Player{Color: engine.WhiteColor}
+		oponentColor := engine.BlackColor
+		if gameDb.BlackPlayerID.Valid && v.PlayerID == uint(gameDb.BlackPlayerID.Int32) {
+			player.Color, oponentColor = engine.BlackColor, engine.WhiteColor
+		}
+		from, _ := engine.StringToSquareIdentifier(v.From)
+		to, _ := engine.StringToSquareIdentifier(v.To)
+		m := engine.Movement{
+			Player:     player,
+			PieceMoved: engine.PieceFromPieceIdentifier(engine.PieceIdentifier(v.PieceMoved), player.Color),
+			PieceEaten: engine.PieceFromPieceIdentifier(engine.PieceIdentifier(v.PieceEaten), oponentColor),
+			From:       from,
+			To:         to,
+			EnPassant:  v.EnPassant,
+			Castling:   v.Castling,
+			Promotion:  engine.PieceFromPieceIdentifier(engine.PieceIdentifier(v.Promotion), player.Color),
+			SAN:        v.SAN,
+		}
+		if v.Castling {
+			m.RookFrom, _ = engine.StringToSquareIdentifier(v.RookFrom)
+			m.RookTo, _ = engine.StringToSquareIdentifier(v.RookTo)
+		}
+		engineMovements = append(engineMovements, m)
+	}
+	return engineMovements
 }
@@ -3,10 +3,12 @@
 package api
 
 import (
+	"database/sql"
 	"fmt"
 	"os"
 	"testing"
 
+	"github.com/dumbogo/chess/engine"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -71,6 +73,42 @@ func TestGameWhere(t *testing.T) {
 	check(rx.Error)
 }
 
+func TestMovementsToEngineMovements(t *testing.T) {
+	assert := assert.New(t)
+	gameDb := Game{
+		WhitePlayerID: sql.NullInt32{Valid: true, Int32: 1},
+		BlackPlayerID: sql.NullInt32{Valid: true, Int32: 2},
+	}
+	engineMovements := []engine.Movement{
+		{
+			Player:     engine.Player{Color: engine.WhiteColor},
+			PieceMoved: engine.NewKing(engine.WhiteColor),
+			From:       engine.E1,
+			To:         engine.G1,
+			Castling:   true,
+			RookFrom:   engine.H1,
+			RookTo:     engine.F1,
+			SAN:        "O-O",
+		},
+		{
+			Player:     engine.Player{Color: engine.BlackColor},
+			PieceMoved: engine.NewPawn(engine.BlackColor),
+			PieceEaten: engine.NewRook(engine.WhiteColor),
+			From:       engine.B2,
+			To:         engine.A1,
+			Promotion:  engine.NewQueen(engine.BlackColor),
+			SAN:        "bxa1=Q",
+		},
+	}
+	movements := []Movement{
+		newMovement(engineMovements[0], 1, 1),
+		newMovement(engineMovements[1], 1, 2),
+	}
+	assert.Equal("E1", movements[0].From)
+	assert.Equal(int(engine.QueenIdentifier), movements[1].Promotion)
+	assert.Equal(engineMovements, movementsToEngineMovements(gameDb, movements))
+}
+
 func check(e error) {
 	if e != nil {
 		panic(e)
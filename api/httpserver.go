@@ -1,18 +1,25 @@
 package api
 
 import (
+	"bytes"
 	"database/sql"
+	"errors"
 	"fmt"
 	"log"
 	"net"
 	"net/http"
 	"net/url"
+	"strconv"
 
+	"github.com/dumbogo/chess/engine"
+	"github.com/dumbogo/chess/engine/diagram"
+	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 	"github.com/gorilla/sessions"
 	"github.com/markbates/goth"
 	"github.com/markbates/goth/gothic"
 	"github.com/markbates/goth/providers/github"
+	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
@@ -43,6 +50,7 @@ func NewHTTPServer(addr url.URL, githubKey string, githubSecret string, sessionK
 	handler := mux.NewRouter()
 	handler.HandleFunc("/auth/{provider}/callback", callbackHandler)
 	handler.HandleFunc("/auth/{provider}", gothic.BeginAuthHandler)
+	handler.HandleFunc("/games/{uuid}/board.{format:svg|png}", boardHandler)
 	handler.HandleFunc("/", rootHandler)
 	provierCallbackURL := fmt.Sprintf("%s://%s/auth/github/callback?provider=github", addr.Scheme, host)
 	goth.UseProviders(
@@ -71,6 +79,49 @@ func rootHandler(w http.ResponseWriter, r *http.Request) {
 	fmt.Fprintf(w, "<p><a href='/auth/github?provider=github'>Click to log in with github</a></p>")
 }
 
+// boardHandler serves the board diagram of a game with its last move highlighted,
+// i.e. /games/{uuid}/board.svg?flip=true&size=640
+func boardHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	if _, err := uuid.Parse(vars["uuid"]); err != nil {
+		http.Error(w, "game not found", http.StatusNotFound)
+		return
+	}
+	options := diagram.Options{Coordinates: true, Flip: r.URL.Query().Get("flip") == "true"}
+	if size := r.URL.Query().Get("size"); size != "" {
+		var err error
+		if options.Size, err = strconv.Atoi(size); err != nil || options.Size < minDiagramSize || options.Size > maxDiagramSize {
+			http.Error(w, fmt.Sprintf("size must be between %d and %d", minDiagramSize, maxDiagramSize), http.StatusBadRequest)
+			return
+		}
+	}
+
+	gameDb := Game{}
+	if tx := DBConn.Where("uuid=?", vars["uuid"]).First(&gameDb); tx.Error != nil {
+		if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+			http.Error(w, "game not found", http.StatusNotFound)
+			return
+		}
+		log.Printf("Loading game %s failed: %v\n", vars["uuid"], tx.Error)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	gameEngine, err := loadEngineGameFromDbValues(gameDb, engine.Player{Color: gameDb.turnColor()})
+	if err != nil {
+		log.Printf("Loading game %s failed: %v\n", vars["uuid"], err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	var b bytes.Buffer
+	if err := diagram.Encode(&b, vars["format"], gameEngine.Board(), diagram.LastMove(gameEngine, options)); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", diagramContentTypes[vars["format"]])
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Write(b.Bytes())
+}
+
 func callbackHandler(w http.ResponseWriter, r *http.Request) {
 	// TODO: needs to delete this, leaving it as project is on development
 	log.Printf("Received request: %+v\n", r)
@@ -3,11 +3,13 @@
 package api
 
 import (
+	"image/png"
 	"io"
 	"log"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -37,3 +39,48 @@ func TestIndex(t *testing.T) {
 	}
 	assert.Equal("<p><a href='/auth/github?provider=github'>Click to log in with github</a></p>", string(body))
 }
+
+func TestBoardDiagram(t *testing.T) {
+	assert := assert.New(t)
+	server := factoryServer()
+	ctx, cancel := createCtxMetadataUser(&User{AccessToken: "hereistoken123", Email: "some@mail.com"})
+	defer cancel()
+	r, err := server.StartGame(ctx, &StartGameRequest{Name: "somename", Color: Color_WHITE})
+	assert.Nil(err)
+
+	s, err := NewHTTPServer(url.URL{Scheme: "", Host: "localhost"}, "githubsecret", "githubkey", "somerandomtext", "development")
+	assert.Nil(err)
+	ts := httptest.NewServer(s.GetHandler())
+	defer ts.Close()
+
+	res, err := http.Get(ts.URL + "/games/" + r.GetUuid() + "/board.svg?flip=true")
+	assert.Nil(err)
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	assert.Nil(err)
+	assert.Equal(http.StatusOK, res.StatusCode)
+	assert.Equal("image/svg+xml", res.Header.Get("Content-Type"))
+	assert.True(strings.HasPrefix(string(body), "<svg "))
+	assert.Contains(string(body), `<rect x="700" y="0" width="100" height="100" fill="#b58863"/>`)
+
+	res, err = http.Get(ts.URL + "/games/" + r.GetUuid() + "/board.png?size=200")
+	assert.Nil(err)
+	img, err := png.Decode(res.Body)
+	res.Body.Close()
+	assert.Nil(err)
+	assert.Equal(200, img.Bounds().Dx())
+
+	// Case: size out of range
+	res, err = http.Get(ts.URL + "/games/" + r.GetUuid() + "/board.png?size=1024")
+	assert.Nil(err)
+	res.Body.Close()
+	assert.Equal(http.StatusBadRequest, res.StatusCode)
+
+	// Case: game not found
+	for _, path := range []string{"/games/notauuid/board.svg", "/games/00000000-0000-0000-0000-000000000000/board.svg"} {
+		res, err = http.Get(ts.URL + path)
+		assert.Nil(err)
+		res.Body.Close()
+		assert.Equal(http.StatusNotFound, res.StatusCode)
+	}
+}
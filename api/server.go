@@ -11,12 +11,14 @@ import (
 	"time"
 
 	"github.com/dumbogo/chess/engine"
+	"github.com/dumbogo/chess/engine/search"
 	"github.com/dumbogo/chess/messagebroker"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	status "google.golang.org/grpc/status"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 var (
@@ -27,6 +29,14 @@ var (
 // MessageBroker a pub/sub messagre broker system to send updates on watchers
 var MessageBroker messagebroker.MessageBroker
 
+var promotionToPieceIdentifier = map[Promotion]engine.PieceIdentifier{
+	Promotion_NO_PROMOTION: 0,
+	Promotion_QUEEN:        engine.QueenIdentifier,
+	Promotion_ROOK:         engine.RookIdentifier,
+	Promotion_BISHOP:       engine.BishopIdentifier,
+	Promotion_KNIGHT:       engine.KnightIdentifier,
+}
+
 // Server grpc server interface implementation
 type Server struct {
 	UnimplementedChessServiceServer
@@ -40,7 +50,23 @@ func (s *Server) StartGame(ctx context.Context, startGameRequest *StartGameReque
 		return nil, e
 	}
 
+	level := 0
+	if startGameRequest.GetOpponent() == Opponent_COMPUTER {
+		level = int(startGameRequest.GetLevel())
+		if level == 0 {
+			level = search.DefaultLevel
+		}
+		if level > search.MaxLevel {
+			return nil, fmt.Errorf("computer level must be between 1 and %d", search.MaxLevel)
+		}
+	}
+
 	game := newGameWithoutPlayers(startGameRequest.GetName())
+	if timeControl := startGameRequest.GetTimeControl(); timeControl != nil {
+		if err := newTimeControl(&game, timeControl); err != nil {
+			return nil, err
+		}
+	}
 
 	switch startGameRequest.GetColor() {
 	case Color_WHITE:
@@ -61,10 +87,32 @@ func (s *Server) StartGame(ctx context.Context, startGameRequest *StartGameReque
 		game.Turn = p.ID
 	}
 
+	// The computer player belongs to the user playing against it
+	if level > 0 {
+		computer := Player{
+			UserID: user.ID,
+			Level:  level,
+		}
+		if startGameRequest.GetColor() == Color_WHITE {
+			computer.Color = Color.Enum(Color_BLACK).String()
+			s.Db.Create(&computer)
+			game.BlackPlayerID = sql.NullInt32{Valid: true, Int32: int32(computer.ID)}
+		} else {
+			computer.Color = Color.Enum(Color_WHITE).String()
+			s.Db.Create(&computer)
+			game.WhitePlayerID = sql.NullInt32{Valid: true, Int32: int32(computer.ID)}
+			game.Turn = computer.ID
+		}
+		game.startClock(time.Now())
+	}
+
 	result := s.Db.Create(&game)
 	if result.Error != nil {
 		return nil, result.Error
 	}
+	if level > 0 && startGameRequest.GetColor() == Color_BLACK {
+		go s.playComputer(game.UUID.String())
+	}
 
 	startGameResponse := &StartGameResponse{
 		Uuid: game.UUID.String(),
@@ -114,6 +162,7 @@ func (s *Server) JoinGame(ctx context.Context, r *JoinGameRequest) (*JoinGameRes
 		}
 		game.BlackPlayerID = sql.NullInt32{Valid: true, Int32: int32(player.ID)}
 	}
+	game.startClock(time.Now())
 
 	tx = s.Db.Save(&game)
 	if tx.Error != nil {
@@ -144,20 +193,34 @@ func (s *Server) Watch(r *WatchRequest, stream ChessService_WatchServer) error {
 		return tx.Error
 	}
 
-	gameEngine, err := loadEngineGameFromDbValues(gameDb, engine.Player{})
+	gameEngine, err := loadEngineGameFromDbValues(gameDb, engine.Player{Color: gameDb.turnColor()})
 	if err != nil {
 		return err
 	}
 
-	turnColor := "white"
-	if gameDb.Turn == uint(gameDb.BlackPlayerID.Int32) {
-		turnColor = "black"
+	var lastMove string
+	if movements := gameEngine.Movements(); len(movements) > 0 {
+		lastMove = movements[len(movements)-1].SAN
 	}
-
+	whiteTime, blackTime := gameDb.timeLeft(time.Now())
+	drawOffer, drawOfferColor := gameDb.requestColor(gameDb.DrawOfferedBy)
+	takebackRequest, takebackRequestColor := gameDb.requestColor(gameDb.TakebackRequestedBy)
 	if err := stream.Send(&WatchResponse{
-		Status: fmt.Sprintf("%s turn", turnColor),
-		Turn:   gameEngine.Turn().Name,
-		Board:  gameEngine.Board().String(),
+		Status:      gameDb.status(),
+		Turn:        gameEngine.Turn().Name,
+		Board:       renderBoard(gameEngine, r.GetBoardStyle()),
+		LastMove:    lastMove,
+		Fen:         gameEngine.FEN(),
+		Moves:       sanMoves(gameEngine),
+		Timed:       gameDb.timed(),
+		WhiteTimeMs: whiteTime.Milliseconds(),
+		BlackTimeMs: blackTime.Milliseconds(),
+
+		DrawOffer:      drawOffer,
+		DrawOfferColor: drawOfferColor,
+
+		TakebackRequest:      takebackRequest,
+		TakebackRequestColor: takebackRequestColor,
 	}); err != nil {
 		return err
 	}
@@ -168,10 +231,28 @@ func (s *Server) Watch(r *WatchRequest, stream ChessService_WatchServer) error {
 		if err := json.Unmarshal(msg.Payload, &payload); err != nil {
 			return err
 		}
+		board := payload.Board
+		if r.GetBoardStyle() != nil && payload.FEN != "" {
+			if game, err := replayMoves(payload.FEN, payload.Moves); err == nil {
+				board = renderBoard(game, r.GetBoardStyle())
+			}
+		}
 		if err := stream.Send(&WatchResponse{
-			Status: payload.Status,
-			Turn:   payload.Turn,
-			Board:  payload.Board,
+			Status:      payload.Status,
+			Turn:        payload.Turn,
+			Board:       board,
+			LastMove:    payload.LastMove,
+			Fen:         payload.FEN,
+			Moves:       payload.Moves,
+			Timed:       payload.Timed,
+			WhiteTimeMs: payload.WhiteTime,
+			BlackTimeMs: payload.BlackTime,
+
+			DrawOffer:      payload.DrawOffer,
+			DrawOfferColor: payload.DrawOfferColor,
+
+			TakebackRequest:      payload.TakebackRequest,
+			TakebackRequestColor: payload.TakebackRequestColor,
 		}); err != nil {
 			return err
 		}
@@ -191,30 +272,13 @@ func (s *Server) Move(ctx context.Context, r *MoveRequest) (*MoveResponse, error
 		return nil, fmt.Errorf("user not found")
 	}
 
-	// Extract game and players from db
-	// TODO: gorm joins func is not working as expected, review
-	gameDb := Game{}
-	tx := DBConn.Where("uuid=?", r.GetUuid()).Joins("join players on players.id = white_player_id").First(&gameDb)
-	if tx.Error != nil {
-		return nil, tx.Error
-	}
-
-	whitePlayerDb := Player{}
-	tx = DBConn.Where("id=?", gameDb.WhitePlayerID.Int32).First(&whitePlayerDb)
-	if tx.Error != nil {
-		if tx.Error == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("white player is missing")
-		}
-		return nil, tx.Error
+	gameDb, whitePlayerDb, blackPlayerDb, err := loadGameAndPlayers(r.GetUuid())
+	if err != nil {
+		return nil, err
 	}
 
-	blackPlayerDb := Player{}
-	tx = DBConn.Where("id=?", gameDb.BlackPlayerID.Int32).First(&blackPlayerDb)
-	if tx.Error != nil {
-		if tx.Error == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("black player is missing")
-		}
-		return nil, tx.Error
+	if gameDb.Winner != 0 {
+		return nil, errors.New(gameDb.status())
 	}
 
 	log.Printf("white player: %+v\n", whitePlayerDb)
@@ -224,11 +288,11 @@ func (s *Server) Move(ctx context.Context, r *MoveRequest) (*MoveResponse, error
 
 	// validate turn color and if user is any player
 	if gameDb.Turn == whitePlayerDb.ID {
-		if user.ID != whitePlayerDb.UserID {
+		if user.ID != whitePlayerDb.UserID || whitePlayerDb.Level > 0 {
 			return nil, errors.New("Not your turn")
 		}
 	} else if gameDb.Turn == blackPlayerDb.ID {
-		if user.ID != blackPlayerDb.UserID {
+		if user.ID != blackPlayerDb.UserID || blackPlayerDb.Level > 0 {
 			return nil, errors.New("Not your turn")
 		}
 	}
@@ -248,38 +312,392 @@ func (s *Server) Move(ctx context.Context, r *MoveRequest) (*MoveResponse, error
 	if err != nil {
 		return nil, err
 	}
-
-	from, ok := engine.StringToSquareIdentifier(strings.ToUpper(r.GetFromSquare()))
-	if !ok {
-		return nil, errors.New("Invalid \"from\" square identifier")
+	if finished, err := finishOnTimeout(&gameDb, gameEngine, time.Now()); err != nil || finished {
+		if err == nil {
+			err = errors.New(gameDb.status())
+		}
+		return nil, err
 	}
 
-	// Review if from piece is from player
-	squareFrom := gameEngine.Board().Squares()[from]
-	if !squareFrom.Empty && squareFrom.Piece.Color() != turnPlayer.Color {
-		return nil, errors.New("Not your piece color")
-	}
+	var from, to engine.SquareIdentifier
+	var promotion engine.PieceIdentifier
+	var ok bool
+	if r.GetSan() != "" {
+		m, err := gameEngine.ParseSAN(r.GetSan())
+		if err != nil {
+			return nil, err
+		}
+		from, to, promotion = m.From, m.To, m.Promotion
+	} else {
+		if from, ok = engine.StringToSquareIdentifier(strings.ToUpper(r.GetFromSquare())); !ok {
+			return nil, errors.New("Invalid \"from\" square identifier")
+		}
 
-	to, ok := engine.StringToSquareIdentifier(strings.ToUpper(r.GetToSquare()))
-	if !ok {
-		return nil, errors.New("Invalid \"to\" square identifier")
+		// Review if from piece is from player
+		squareFrom := gameEngine.Board().Squares()[from]
+		if !squareFrom.Empty && squareFrom.Piece.Color() != turnPlayer.Color {
+			return nil, errors.New("Not your piece color")
+		}
+
+		if to, ok = engine.StringToSquareIdentifier(strings.ToUpper(r.GetToSquare())); !ok {
+			return nil, errors.New("Invalid \"to\" square identifier")
+		}
+
+		if promotion, ok = promotionToPieceIdentifier[r.GetPromotion()]; !ok {
+			return nil, errors.New("Invalid promotion piece")
+		}
 	}
 
-	if ok, e = gameEngine.Move(turnPlayer, from, to); !ok {
+	if ok, e = gameEngine.Move(turnPlayer, from, to, promotion); !ok {
 		return nil, e
 	}
 
-	gameDb.Turn = nextTurn
-	if err := updateGameValuesFromGameEngine(&gameDb, gameEngine); err != nil {
+	playerID, nextPlayer := whitePlayerDb.ID, blackPlayerDb
+	if turnPlayer.Color == engine.BlackColor {
+		playerID, nextPlayer = blackPlayerDb.ID, whitePlayerDb
+	}
+	if err := saveMovement(&gameDb, gameEngine, playerID, nextTurn); err != nil {
 		return nil, err
 	}
+	if nextPlayer.Level > 0 && gameDb.Winner == 0 {
+		go s.playComputer(gameDb.UUID.String())
+	}
 
+	movements := gameEngine.Movements()
+	lastMovement := movements[len(movements)-1]
 	// TODO: Send response checkmate or check when it happens
 	return &MoveResponse{
-		Board: gameEngine.Board().String(),
+		Board: renderBoard(gameEngine, r.GetBoardStyle()),
+		San:   lastMovement.SAN,
+	}, nil
+}
+
+// playComputer plays the computer turn of game uuid, errors are logged as no request waits for them
+func (s *Server) playComputer(uuid string) {
+	if err := computerMove(uuid); err != nil {
+		log.Printf("Computer move failed on game %s: %v\n", uuid, err)
+	}
+}
+
+// computerMove searches and saves the computer movement of game uuid, if it is the computer turn.
+// Failures are counted on the game, the sweeper gives up on it after maxComputerFailures
+func computerMove(uuid string) error {
+	gameDb, whitePlayerDb, blackPlayerDb, err := loadGameAndPlayers(uuid)
+	if err != nil {
+		return err
+	}
+	computer, nextTurn := whitePlayerDb, blackPlayerDb.ID
+	turnPlayer := engine.Player{Color: engine.WhiteColor}
+	if gameDb.Turn == blackPlayerDb.ID {
+		computer, nextTurn = blackPlayerDb, whitePlayerDb.ID
+		turnPlayer.Color = engine.BlackColor
+	}
+	if gameDb.Winner != 0 || gameDb.Turn != computer.ID || computer.Level == 0 {
+		return nil
+	}
+	if err := searchComputerMove(&gameDb, turnPlayer, computer, nextTurn); err != nil {
+		// updated_at is kept, so the sweeper retries it computerRetryAfter since the last movement
+		if tx := DBConn.Model(&Game{}).Where("id = ?", gameDb.ID).UpdateColumn("computer_failures", gorm.Expr("computer_failures + 1")); tx.Error != nil {
+			log.Printf("failed to count the computer failure on game %s: %v", uuid, tx.Error)
+		}
+		return err
+	}
+	return nil
+}
+
+// searchComputerMove searches and saves the movement of computer, the player in turn of gameDb
+func searchComputerMove(gameDb *Game, turnPlayer engine.Player, computer Player, nextTurn uint) error {
+	gameEngine, err := loadEngineGameWithBoard(*gameDb, turnPlayer, engine.LoadBitboard)
+	if err != nil {
+		return err
+	}
+	if finished, err := finishOnTimeout(gameDb, gameEngine, time.Now()); err != nil || finished {
+		return err
+	}
+	result, err := search.New(computerHashSize).Search(context.Background(), gameEngine, search.LevelLimits(computer.Level))
+	if err != nil {
+		return err
+	}
+	if ok, err := gameEngine.Move(turnPlayer, result.Move.From, result.Move.To, result.Move.Promotion); !ok {
+		return err
+	}
+	return saveMovement(gameDb, gameEngine, computer.ID, nextTurn)
+}
+
+// ExportPGN returns a game in Portable Game Notation
+func (s *Server) ExportPGN(ctx context.Context, r *ExportPGNRequest) (*ExportPGNResponse, error) {
+	gameDb := Game{}
+	tx := DBConn.Where("uuid=?", r.GetUuid()).First(&gameDb)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	turnPlayer := engine.Player{Color: engine.WhiteColor}
+	if gameDb.BlackPlayerID.Valid && gameDb.Turn == uint(gameDb.BlackPlayerID.Int32) {
+		turnPlayer.Color = engine.BlackColor
+	}
+	gameEngine, err := loadEngineGameFromDbValues(gameDb, turnPlayer)
+	if err != nil {
+		return nil, err
+	}
+
+	tags := map[string]string{
+		"Date": gameDb.CreatedAt.Format("2006.01.02"),
+	}
+	for tag, playerID := range map[string]sql.NullInt32{"White": gameDb.WhitePlayerID, "Black": gameDb.BlackPlayerID} {
+		if !playerID.Valid {
+			continue
+		}
+		player := Player{}
+		tx = DBConn.Preload("User").Where("id=?", playerID.Int32).First(&player)
+		if tx.Error != nil {
+			return nil, tx.Error
+		}
+		if player.Level > 0 {
+			tags[tag] = fmt.Sprintf("Computer level %d", player.Level)
+		} else if player.User.Name != "" {
+			tags[tag] = player.User.Name
+		}
+	}
+	return &ExportPGNResponse{
+		Pgn: gameEngine.PGN(tags),
 	}, nil
 }
 
+// Resign finishes the game, won by the oponent of the user
+func (s *Server) Resign(ctx context.Context, r *ResignRequest) (*ResignResponse, error) {
+	gameDb, player, _, err := loadUserGame(ctx, r.GetUuid())
+	if err != nil {
+		return nil, err
+	}
+	if err := finishByPlayer(&gameDb, oponentWins(gameDb.playerColor(player)), engine.ResignationReason); err != nil {
+		return nil, err
+	}
+	return &ResignResponse{Status: gameDb.status()}, nil
+}
+
+// OfferDraw offers a draw to the oponent of the user, pending until the oponent responds or moves
+func (s *Server) OfferDraw(ctx context.Context, r *OfferDrawRequest) (*OfferDrawResponse, error) {
+	gameDb, player, oponent, err := loadUserGame(ctx, r.GetUuid())
+	if err != nil {
+		return nil, err
+	}
+	if oponent.Level > 0 {
+		return nil, errors.New("the computer does not accept draws")
+	}
+	if gameDb.DrawOfferedBy.Valid {
+		if uint(gameDb.DrawOfferedBy.Int32) == player.ID {
+			return nil, errors.New("draw already offered")
+		}
+		return nil, errors.New("your oponent offered a draw, either accept or decline it")
+	}
+	if err := updateDrawOffer(&gameDb, sql.NullInt32{Int32: int32(player.ID), Valid: true}); err != nil {
+		return nil, err
+	}
+	return &OfferDrawResponse{Status: gameDb.status()}, nil
+}
+
+// RespondDraw accepts, finishing the game drawn by agreement, or declines the draw offered by the oponent of the user
+func (s *Server) RespondDraw(ctx context.Context, r *RespondDrawRequest) (*RespondDrawResponse, error) {
+	gameDb, player, _, err := loadUserGame(ctx, r.GetUuid())
+	if err != nil {
+		return nil, err
+	}
+	if !gameDb.DrawOfferedBy.Valid || uint(gameDb.DrawOfferedBy.Int32) == player.ID {
+		return nil, errors.New("no draw offered by your oponent")
+	}
+	if r.GetAccept() {
+		err = finishByPlayer(&gameDb, engine.DrawResult, engine.AgreementReason)
+	} else {
+		err = updateDrawOffer(&gameDb, sql.NullInt32{})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &RespondDrawResponse{Status: gameDb.status()}, nil
+}
+
+// RequestTakeback asks the oponent of the user to undo the last movement of the user, along with the oponent
+// reply to it, pending until the oponent responds or a movement is made. The computer accepts it on the user turn
+func (s *Server) RequestTakeback(ctx context.Context, r *RequestTakebackRequest) (*RequestTakebackResponse, error) {
+	gameDb, player, oponent, err := loadUserGame(ctx, r.GetUuid())
+	if err != nil {
+		return nil, err
+	}
+	var moved int64
+	if tx := DBConn.Model(&Movement{}).Where("game_id = ? AND player_id = ?", gameDb.ID, player.ID).Count(&moved); tx.Error != nil {
+		return nil, tx.Error
+	}
+	if moved == 0 {
+		return nil, errors.New("no movement to take back")
+	}
+	if oponent.Level > 0 {
+		if gameDb.Turn == oponent.ID {
+			return nil, errors.New("the computer is thinking, request the takeback after its movement")
+		}
+		if err := takeback(&gameDb, gameDb.playerColor(player)); err != nil {
+			return nil, err
+		}
+		return &RequestTakebackResponse{Status: gameDb.status()}, nil
+	}
+	if gameDb.TakebackRequestedBy.Valid {
+		if uint(gameDb.TakebackRequestedBy.Int32) == player.ID {
+			return nil, errors.New("takeback already requested")
+		}
+		return nil, errors.New("your oponent requested a takeback, either accept or decline it")
+	}
+	if err := updateTakebackRequest(&gameDb, sql.NullInt32{Int32: int32(player.ID), Valid: true}); err != nil {
+		return nil, err
+	}
+	return &RequestTakebackResponse{Status: gameDb.status()}, nil
+}
+
+// RespondTakeback accepts, undoing the movements, or declines the takeback requested by the oponent of the user
+func (s *Server) RespondTakeback(ctx context.Context, r *RespondTakebackRequest) (*RespondTakebackResponse, error) {
+	gameDb, player, oponent, err := loadUserGame(ctx, r.GetUuid())
+	if err != nil {
+		return nil, err
+	}
+	if !gameDb.TakebackRequestedBy.Valid || uint(gameDb.TakebackRequestedBy.Int32) == player.ID {
+		return nil, errors.New("no takeback requested by your oponent")
+	}
+	if r.GetAccept() {
+		err = takeback(&gameDb, gameDb.playerColor(oponent))
+	} else {
+		err = updateTakebackRequest(&gameDb, sql.NullInt32{})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &RespondTakebackResponse{Status: gameDb.status()}, nil
+}
+
+// loadUserGame returns the ongoing game uuid, the player of the user and its oponent
+func loadUserGame(ctx context.Context, uuid string) (Game, Player, Player, error) {
+	user, err := getUserFromCtx(ctx)
+	if err != nil {
+		return Game{}, Player{}, Player{}, err
+	}
+	if user == nil {
+		return Game{}, Player{}, Player{}, fmt.Errorf("user not found")
+	}
+	gameDb, whitePlayerDb, blackPlayerDb, err := loadGameAndPlayers(uuid)
+	if err != nil {
+		return Game{}, Player{}, Player{}, err
+	}
+	if gameDb.Winner != 0 {
+		return Game{}, Player{}, Player{}, errors.New(gameDb.status())
+	}
+	switch {
+	case whitePlayerDb.UserID == user.ID && whitePlayerDb.Level == 0:
+		return gameDb, whitePlayerDb, blackPlayerDb, nil
+	case blackPlayerDb.UserID == user.ID && blackPlayerDb.Level == 0:
+		return gameDb, blackPlayerDb, whitePlayerDb, nil
+	}
+	return Game{}, Player{}, Player{}, errors.New("Not a player of the game")
+}
+
+// finishByPlayer finishes the game with result by reason, unless the time of the player in turn ran out before
+func finishByPlayer(gameDb *Game, result engine.Result, reason engine.Reason) error {
+	gameEngine, err := loadEngineGameFromDbValues(*gameDb, engine.Player{Color: gameDb.turnColor()})
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	if finished, err := finishOnTimeout(gameDb, gameEngine, now); err != nil || finished {
+		if err == nil {
+			err = errors.New(gameDb.status())
+		}
+		return err
+	}
+	finished, err := finishGame(gameDb, gameEngine, result, reason, now)
+	if err != nil || finished {
+		return err
+	}
+	if gameDb.Winner != 0 {
+		return errors.New(gameDb.status())
+	}
+	return errors.New("game changed meanwhile, please try again")
+}
+
+// updateDrawOffer sets the draw offered on the ongoing game, notifying watchers
+func updateDrawOffer(gameDb *Game, offeredBy sql.NullInt32) error {
+	if err := updateRequest(gameDb, "draw_offered_by", offeredBy); err != nil {
+		return err
+	}
+	gameDb.DrawOfferedBy = offeredBy
+	return gameDb.AfterSave(DBConn)
+}
+
+// updateTakebackRequest sets the takeback requested on the ongoing game, notifying watchers
+func updateTakebackRequest(gameDb *Game, requestedBy sql.NullInt32) error {
+	if err := updateRequest(gameDb, "takeback_requested_by", requestedBy); err != nil {
+		return err
+	}
+	gameDb.TakebackRequestedBy = requestedBy
+	return gameDb.AfterSave(DBConn)
+}
+
+// updateRequest sets column, the player making a request to its oponent, on the ongoing game.
+// Returns the game status as error if it finished
+func updateRequest(gameDb *Game, column string, playerID sql.NullInt32) error {
+	tx := DBConn.Table("games").Where("id = ? AND winner = 0", gameDb.ID).Update(column, playerID)
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		if tx := DBConn.Select("winner", "reason").Where("id = ?", gameDb.ID).Take(gameDb); tx.Error != nil {
+			return tx.Error
+		}
+		return errors.New(gameDb.status())
+	}
+	return nil
+}
+
+// takeback undoes the last movement of the player of color, along with the oponent reply to it,
+// rolling back the movements and the board stored at once
+func takeback(gameDb *Game, color engine.Color) error {
+	gameEngine, err := loadEngineGameFromDbValues(*gameDb, engine.Player{Color: gameDb.turnColor()})
+	if err != nil {
+		return err
+	}
+	movements := gameEngine.Movements()
+	weight := 1
+	if len(movements) > 0 && movements[len(movements)-1].Player.Color != color {
+		weight = 2
+	}
+	if len(movements) < weight {
+		return errors.New("no movement to take back")
+	}
+	gameEngine.Rollback(weight)
+
+	gameDb.restartClock(time.Now())
+	gameDb.Turn = uint(gameDb.WhitePlayerID.Int32)
+	if gameEngine.Turn().Color == engine.BlackColor {
+		gameDb.Turn = uint(gameDb.BlackPlayerID.Int32)
+	}
+	gameDb.TakebackRequestedBy = sql.NullInt32{}
+	gameDb.LastMove = ""
+	if remaining := gameEngine.Movements(); len(remaining) > 0 {
+		gameDb.LastMove = remaining[len(remaining)-1].SAN
+	}
+	gameDb.FEN = gameEngine.FEN()
+	gameDb.Moves = sanMoves(gameEngine)
+	return DBConn.Transaction(func(tx *gorm.DB) error {
+		if err := lockGame(tx, gameDb.ID, len(movements)); err != nil {
+			return err
+		}
+		var ids []uint
+		if tx := tx.Model(&Movement{}).Where("game_id = ?", gameDb.ID).Order("id desc").Limit(weight).Pluck("id", &ids); tx.Error != nil {
+			return tx.Error
+		}
+		if tx := tx.Delete(&Movement{}, ids); tx.Error != nil {
+			return tx.Error
+		}
+		return updateGameValuesFromGameEngine(tx, gameDb, gameEngine)
+	})
+}
+
 // EnsureValidToken ensures a valid token exists within a request's metadata. If
 // the token is missing or invalid, the interceptor blocks execution of the
 // handler and returns an error. Otherwise, the interceptor invokes the unary
@@ -299,9 +717,14 @@ func EnsureValidToken(ctx context.Context, req interface{}, info *grpc.UnaryServ
 }
 
 func loadEngineGameFromDbValues(gameDb Game, turn engine.Player) (engine.Game, error) {
+	return loadEngineGameWithBoard(gameDb, turn, engine.LoadBoard)
+}
+
+// loadEngineGameWithBoard loads gameDb played on the Board created by loader, i.e. engine.LoadBitboard for searches
+func loadEngineGameWithBoard(gameDb Game, turn engine.Player, loader engine.BoardLoader) (engine.Game, error) {
 	whitePlayer := engine.Player{Color: engine.WhiteColor}
 	blackPlayer := engine.Player{Color: engine.BlackColor}
-	board := engine.LoadBoard(&whitePlayer, &blackPlayer, squaresToEngineSquares(gameDb.BoardSquares))
+	board := loader(&whitePlayer, &blackPlayer, squaresToEngineSquares(gameDb.BoardSquares))
 	whitePieces := engine.PiecesList{}
 	for i, v := range gameDb.WhitePieces {
 		whitePieces[engine.PieceIdentifier(i)] = v
@@ -310,8 +733,12 @@ func loadEngineGameFromDbValues(gameDb Game, turn engine.Player) (engine.Game, e
 	for i, v := range gameDb.BlackPieces {
 		blackPieces[engine.PieceIdentifier(i)] = v
 	}
+	movements := []Movement{}
+	if tx := DBConn.Where("game_id=?", gameDb.ID).Order("id").Find(&movements); tx.Error != nil {
+		return nil, tx.Error
+	}
 
-	return engine.LoadGame(
+	gameEngine, err := engine.LoadGame(
 		gameDb.Name,
 		board,
 		turn,
@@ -319,11 +746,139 @@ func loadEngineGameFromDbValues(gameDb Game, turn engine.Player) (engine.Game, e
 		blackPlayer,
 		whitePieces,
 		blackPieces,
-		make([]engine.Movement, 0), // Movements, leaving empty ATM
+		movementsToEngineMovements(gameDb, movements),
 	)
+	if err != nil {
+		return nil, err
+	}
+	// results not given by the position, i.e. resignation, are only stored
+	if gameDb.Winner != 0 && gameDb.Reason != 0 && gameEngine.Status().Result == engine.OngoingResult {
+		if err := gameEngine.Finish(engine.Result(gameDb.Winner), engine.Reason(gameDb.Reason)); err != nil {
+			return nil, err
+		}
+	}
+	return gameEngine, nil
+}
+
+// loadGameAndPlayers returns game uuid with its white and black players
+func loadGameAndPlayers(uuid string) (Game, Player, Player, error) {
+	// TODO: gorm joins func is not working as expected, review
+	gameDb := Game{}
+	tx := DBConn.Where("uuid=?", uuid).Joins("join players on players.id = white_player_id").First(&gameDb)
+	if tx.Error != nil {
+		return Game{}, Player{}, Player{}, tx.Error
+	}
+
+	whitePlayerDb := Player{}
+	tx = DBConn.Where("id=?", gameDb.WhitePlayerID.Int32).First(&whitePlayerDb)
+	if tx.Error != nil {
+		if tx.Error == gorm.ErrRecordNotFound {
+			return Game{}, Player{}, Player{}, fmt.Errorf("white player is missing")
+		}
+		return Game{}, Player{}, Player{}, tx.Error
+	}
+
+	blackPlayerDb := Player{}
+	tx = DBConn.Where("id=?", gameDb.BlackPlayerID.Int32).First(&blackPlayerDb)
+	if tx.Error != nil {
+		if tx.Error == gorm.ErrRecordNotFound {
+			return Game{}, Player{}, Player{}, fmt.Errorf("black player is missing")
+		}
+		return Game{}, Player{}, Player{}, tx.Error
+	}
+	return gameDb, whitePlayerDb, blackPlayerDb, nil
+}
+
+// saveMovement stores the last movement of gameEngine made by playerID, passing the turn to nextTurn
+func saveMovement(gameDb *Game, gameEngine engine.Game, playerID, nextTurn uint) error {
+	movements := gameEngine.Movements()
+	lastMovement := movements[len(movements)-1]
+	gameDb.pressClock(time.Now())
+	// moving declines the draw offered by the oponent, and any takeback requested
+	if gameDb.DrawOfferedBy.Valid && gameDb.DrawOfferedBy.Int32 != int32(playerID) {
+		gameDb.DrawOfferedBy = sql.NullInt32{}
+	}
+	gameDb.TakebackRequestedBy = sql.NullInt32{}
+	gameDb.ComputerFailures = 0
+	gameDb.Turn = nextTurn
+	gameDb.LastMove = lastMovement.SAN
+	gameDb.FEN = gameEngine.FEN()
+	gameDb.Moves = sanMoves(gameEngine)
+	if gameStatus := gameEngine.Status(); gameStatus.Result != engine.OngoingResult {
+		gameDb.Winner, gameDb.Reason = int(gameStatus.Result), int(gameStatus.Reason)
+	}
+	return DBConn.Transaction(func(tx *gorm.DB) error {
+		// locks the game, so it is not finished by the sweeper nor taken back meanwhile
+		if err := lockGame(tx, gameDb.ID, len(movements)-1); err != nil {
+			return err
+		}
+		movementDb := newMovement(lastMovement, gameDb.ID, playerID)
+		if tx := tx.Create(&movementDb); tx.Error != nil {
+			return tx.Error
+		}
+		return updateGameValuesFromGameEngine(tx, gameDb, gameEngine)
+	})
+}
+
+// lockGame locks the ongoing game gameID until tx finishes, checking it has the movements the change was made on
+func lockGame(tx *gorm.DB, gameID uint, movements int) error {
+	current := Game{}
+	if tx := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("winner", "reason").Where("id = ?", gameID).Take(&current); tx.Error != nil {
+		return tx.Error
+	}
+	if current.Winner != 0 {
+		return errors.New(current.status())
+	}
+	var count int64
+	if tx := tx.Model(&Movement{}).Where("game_id = ?", gameID).Count(&count); tx.Error != nil {
+		return tx.Error
+	}
+	if int(count) != movements {
+		return errors.New("game changed meanwhile, please try again")
+	}
+	return nil
+}
+
+// sanMoves returns every movement of gameEngine in SAN
+func sanMoves(gameEngine engine.Game) []string {
+	movements := gameEngine.Movements()
+	moves := make([]string, len(movements))
+	for i, m := range movements {
+		moves[i] = m.SAN
+	}
+	return moves
+}
+
+// renderBoard returns the board of gameEngine drawn with style, as a table when style is nil
+func renderBoard(gameEngine engine.Game, style *BoardStyle) string {
+	if style == nil {
+		return gameEngine.Board().String()
+	}
+	return gameEngine.Render(engine.RenderOptions{
+		Unicode:     style.GetUnicode(),
+		Color:       style.GetColor(),
+		Coordinates: style.GetCoordinates(),
+		Flip:        style.GetFlip(),
+	})
+}
+
+// replayMoves returns the game reaching fen by playing moves in SAN from the starting position
+func replayMoves(fen string, moves []string) (engine.Game, error) {
+	game, _ := engine.ParseFEN(engine.StartingFEN)
+	for _, san := range moves {
+		m, err := game.ParseSAN(san)
+		if err != nil {
+			return nil, err
+		}
+		game.Move(game.Turn(), m.From, m.To, m.Promotion)
+	}
+	if game.FEN() != fen {
+		return nil, fmt.Errorf("moves do not reach %q", fen)
+	}
+	return game, nil
 }
 
-func updateGameValuesFromGameEngine(gameDb *Game, gameEngine engine.Game) error {
+func updateGameValuesFromGameEngine(tx *gorm.DB, gameDb *Game, gameEngine engine.Game) error {
 	newBlackPiecesDb := pieces{}
 	for i, v := range gameEngine.BlackPieces() {
 		newBlackPiecesDb[uint8(i)] = v
@@ -335,7 +890,8 @@ func updateGameValuesFromGameEngine(gameDb *Game, gameEngine engine.Game) error
 	}
 	gameDb.WhitePieces = newWhitePiecesDb
 	gameDb.BoardSquares = engineSquaresToSquares(gameEngine.Board().Squares())
-	if tx := DBConn.Save(&gameDb); tx.Error != nil {
+	gameDb.Hash = int64(gameEngine.Hash())
+	if tx := tx.Save(&gameDb); tx.Error != nil {
 		return tx.Error
 	}
 	return nil
@@ -387,8 +943,10 @@ func newGameWithoutPlayers(name string) Game {
 		}
 		squares[i] = sq
 	}
+	starting, _ := engine.ParseFEN(engine.StartingFEN)
 	return Game{
 		Name: name,
+		Hash: int64(starting.Hash()),
 		BlackPieces: map[uint8]uint8{
 			uint8(engine.RookIdentifier):   2,
 			uint8(engine.KnightIdentifier): 2,
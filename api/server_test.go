@@ -4,10 +4,13 @@ package api
 
 import (
 	context "context"
+	"encoding/json"
 	"fmt"
 	"testing"
 	"time"
 
+	"github.com/dumbogo/chess/engine"
+	"github.com/dumbogo/chess/messagebroker"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc/metadata"
@@ -91,6 +94,377 @@ func TestServerMove(t *testing.T) {
 	moveResponse, err = server.Move(ctxMove2, &MoveRequest{Uuid: r.GetUuid(), Color: Color_BLACK, FromSquare: "G7", ToSquare: "G5"})
 	assert.Nil(err)
 	assert.NotEmpty(moveResponse)
+	assert.Equal("g5", moveResponse.GetSan())
+
+	moveResponse, err = server.Move(ctxMove, &MoveRequest{Uuid: r.GetUuid(), Color: Color_WHITE, San: "Nf3", BoardStyle: &BoardStyle{Coordinates: true}})
+	assert.Nil(err)
+	assert.Equal("Nf3", moveResponse.GetSan())
+	assert.Equal(`8  r  n  b  q  k  b  n  r
+7  p  p  p  p  p  p  .  p
+6  .  .  .  .  .  .  .  .
+5  .  .  .  .  .  .  p  .
+4  .  .  .  .  P  .  .  .
+3  .  .  .  .  . [N] .  .
+2  P  P  P  P  .  P  P  P
+1  R  N  B  Q  K  B [.] R
+   a  b  c  d  e  f  g  h
+`, moveResponse.GetBoard())
+}
+
+// fakeBroker delivers messages to every subscriber, then closes the subscription
+type fakeBroker struct {
+	messages []messagebroker.Message
+}
+
+func (fakeBroker) Publish(topic string, message ...messagebroker.Message) error { return nil }
+
+func (b fakeBroker) Subscribe(topic string) (chan messagebroker.Message, error) {
+	ch := make(chan messagebroker.Message, len(b.messages))
+	for _, m := range b.messages {
+		ch <- m
+	}
+	close(ch)
+	return ch, nil
+}
+
+func (fakeBroker) Close() {}
+
+// fakeWatchServer records the responses sent
+type fakeWatchServer struct {
+	ChessService_WatchServer
+	responses []*WatchResponse
+}
+
+func (s *fakeWatchServer) Send(r *WatchResponse) error {
+	s.responses = append(s.responses, r)
+	return nil
+}
+
+func TestServerWatch(t *testing.T) {
+	assert := assert.New(t)
+	server := factoryServer()
+	MessageBroker = fakeBroker{}
+	defer func() { MessageBroker = nil }()
+	ctx, cancel := createCtxMetadataUser(&User{AccessToken: "hereistoken123", Email: "some@mail.com"})
+	defer cancel()
+	r, err := server.StartGame(ctx, &StartGameRequest{Name: "somename", Color: Color_WHITE})
+	assert.Nil(err)
+
+	stream := &fakeWatchServer{}
+	assert.Nil(server.Watch(&WatchRequest{Uuid: r.GetUuid()}, stream))
+	assert.Len(stream.responses, 1)
+	assert.Equal(engine.StartingFEN, stream.responses[0].GetFen())
+
+	// Case: black to move
+	ctxJoin, cancelJoin := createCtxMetadataUser(&User{AccessToken: "someothertoken", Email: "other@mail.com"})
+	defer cancelJoin()
+	_, err = server.JoinGame(ctxJoin, &JoinGameRequest{Uuid: r.GetUuid()})
+	assert.Nil(err)
+	_, err = server.Move(ctx, &MoveRequest{Uuid: r.GetUuid(), San: "e4"})
+	assert.Nil(err)
+	stream = &fakeWatchServer{}
+	assert.Nil(server.Watch(&WatchRequest{Uuid: r.GetUuid()}, stream))
+	assert.Equal("rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq e3 0 1", stream.responses[0].GetFen())
+	assert.Equal([]string{"e4"}, stream.responses[0].GetMoves())
+
+	// Case: boards drawn with the style requested, updates included
+	payload, err := json.Marshal(payloadUpdateGame{
+		Board: "table",
+		FEN:   "rnbqkbnr/pppp1ppp/8/4p3/4P3/8/PPPP1PPP/RNBQKBNR w KQkq e6 0 2",
+		Moves: []string{"e4", "e5"},
+	})
+	assert.Nil(err)
+	MessageBroker = fakeBroker{messages: []messagebroker.Message{{Payload: payload}}}
+	style := &BoardStyle{Coordinates: true, Flip: true}
+	stream = &fakeWatchServer{}
+	assert.Nil(server.Watch(&WatchRequest{Uuid: r.GetUuid(), BoardStyle: style}, stream))
+	assert.Len(stream.responses, 2)
+	game, err := replayMoves("rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq e3 0 1", []string{"e4"})
+	assert.Nil(err)
+	assert.Equal(renderBoard(game, style), stream.responses[0].GetBoard())
+	assert.NotEqual(game.Board().String(), stream.responses[0].GetBoard())
+	game, err = replayMoves("rnbqkbnr/pppp1ppp/8/4p3/4P3/8/PPPP1PPP/RNBQKBNR w KQkq e6 0 2", []string{"e4", "e5"})
+	assert.Nil(err)
+	assert.Equal(renderBoard(game, style), stream.responses[1].GetBoard())
+	_, err = replayMoves(engine.StartingFEN, []string{"e4"})
+	assert.EqualError(err, "moves do not reach \"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1\"")
+}
+
+func TestServerClock(t *testing.T) {
+	assert := assert.New(t)
+	server := factoryServer()
+	ctx, cancel := createCtxMetadataUser(&User{AccessToken: "hereistoken123", Email: "some@mail.com"})
+	defer cancel()
+	_, err := server.StartGame(ctx, &StartGameRequest{Name: "somename", Color: Color_WHITE, TimeControl: &TimeControl{IncrementSeconds: 2}})
+	assert.EqualError(err, "time control increment requires a base time")
+
+	r, err := server.StartGame(ctx, &StartGameRequest{Name: "somename", Color: Color_WHITE, TimeControl: &TimeControl{BaseSeconds: 60, IncrementSeconds: 2}})
+	assert.Nil(err)
+	ctxJoin, cancelJoin := createCtxMetadataUser(&User{AccessToken: "someothertoken", Email: "other@mail.com"})
+	defer cancelJoin()
+	_, err = server.JoinGame(ctxJoin, &JoinGameRequest{Uuid: r.GetUuid()})
+	assert.Nil(err)
+	_, err = server.Move(ctx, &MoveRequest{Uuid: r.GetUuid(), San: "e4"})
+	assert.Nil(err)
+
+	var gameDb Game
+	assert.Nil(DBConn.Where("uuid = ?", r.GetUuid()).First(&gameDb).Error)
+	assert.True(gameDb.TurnStartedAt.Valid)
+	assert.Greater(int64(gameDb.WhiteTime), int64(time.Minute))
+	assert.Equal(time.Minute, gameDb.BlackTime)
+
+	// Case: black flag falls
+	assert.Nil(DBConn.Model(&gameDb).Update("turn_started_at", time.Now().Add(-2*time.Minute)).Error)
+	_, err = server.Move(ctxJoin, &MoveRequest{Uuid: r.GetUuid(), San: "e5"})
+	assert.EqualError(err, "game finished, white wins by timeout")
+	assert.Nil(DBConn.Where("uuid = ?", r.GetUuid()).First(&gameDb).Error)
+	assert.Equal(int(engine.WhiteWinsResult), gameDb.Winner)
+	assert.Equal(time.Duration(0), gameDb.BlackTime)
+}
+
+func TestServerResignAndDraw(t *testing.T) {
+	assert := assert.New(t)
+	server := factoryServer()
+	ctx, cancel := createCtxMetadataUser(&User{AccessToken: "hereistoken123", Email: "some@mail.com"})
+	defer cancel()
+	ctxJoin, cancelJoin := createCtxMetadataUser(&User{AccessToken: "someothertoken", Email: "other@mail.com"})
+	defer cancelJoin()
+	ctxOther, cancelOther := createCtxMetadataUser(&User{AccessToken: "anothertoken", Email: "another@mail.com"})
+	defer cancelOther()
+
+	r, err := server.StartGame(ctx, &StartGameRequest{Name: "somename", Color: Color_WHITE})
+	assert.Nil(err)
+	_, err = server.JoinGame(ctxJoin, &JoinGameRequest{Uuid: r.GetUuid()})
+	assert.Nil(err)
+	_, err = server.Move(ctx, &MoveRequest{Uuid: r.GetUuid(), San: "e4"})
+	assert.Nil(err)
+
+	_, err = server.OfferDraw(ctxOther, &OfferDrawRequest{Uuid: r.GetUuid()})
+	assert.EqualError(err, "Not a player of the game")
+	_, err = server.OfferDraw(ctxJoin, &OfferDrawRequest{Uuid: r.GetUuid()})
+	assert.Nil(err)
+	_, err = server.OfferDraw(ctxJoin, &OfferDrawRequest{Uuid: r.GetUuid()})
+	assert.EqualError(err, "draw already offered")
+	_, err = server.OfferDraw(ctx, &OfferDrawRequest{Uuid: r.GetUuid()})
+	assert.EqualError(err, "your oponent offered a draw, either accept or decline it")
+	_, err = server.RespondDraw(ctxJoin, &RespondDrawRequest{Uuid: r.GetUuid(), Accept: true})
+	assert.EqualError(err, "no draw offered by your oponent")
+
+	// the offer stands after the player offering moves
+	_, err = server.Move(ctxJoin, &MoveRequest{Uuid: r.GetUuid(), San: "e5"})
+	assert.Nil(err)
+	drawResponse, err := server.RespondDraw(ctx, &RespondDrawRequest{Uuid: r.GetUuid()})
+	assert.Nil(err)
+	assert.Equal("white turn", drawResponse.GetStatus())
+	var gameDb Game
+	assert.Nil(DBConn.Where("uuid = ?", r.GetUuid()).First(&gameDb).Error)
+	assert.False(gameDb.DrawOfferedBy.Valid)
+
+	// Case: moving declines the offer
+	_, err = server.OfferDraw(ctxJoin, &OfferDrawRequest{Uuid: r.GetUuid()})
+	assert.Nil(err)
+	_, err = server.Move(ctx, &MoveRequest{Uuid: r.GetUuid(), San: "Nf3"})
+	assert.Nil(err)
+	assert.Nil(DBConn.Where("uuid = ?", r.GetUuid()).First(&gameDb).Error)
+	assert.False(gameDb.DrawOfferedBy.Valid)
+
+	_, err = server.OfferDraw(ctx, &OfferDrawRequest{Uuid: r.GetUuid()})
+	assert.Nil(err)
+	drawResponse, err = server.RespondDraw(ctxJoin, &RespondDrawRequest{Uuid: r.GetUuid(), Accept: true})
+	assert.Nil(err)
+	assert.Equal("game finished, draw by agreement", drawResponse.GetStatus())
+	_, err = server.Move(ctxJoin, &MoveRequest{Uuid: r.GetUuid(), San: "Nc6"})
+	assert.EqualError(err, "game finished, draw by agreement")
+	_, err = server.Resign(ctxJoin, &ResignRequest{Uuid: r.GetUuid()})
+	assert.EqualError(err, "game finished, draw by agreement")
+	exportResponse, err := server.ExportPGN(ctx, &ExportPGNRequest{Uuid: r.GetUuid()})
+	assert.Nil(err)
+	assert.Contains(exportResponse.GetPgn(), "[Result \"1/2-1/2\"]\n")
+
+	// Case: resign
+	r, err = server.StartGame(ctx, &StartGameRequest{Name: "somename", Color: Color_WHITE})
+	assert.Nil(err)
+	_, err = server.JoinGame(ctxJoin, &JoinGameRequest{Uuid: r.GetUuid()})
+	assert.Nil(err)
+	resignResponse, err := server.Resign(ctx, &ResignRequest{Uuid: r.GetUuid()})
+	assert.Nil(err)
+	assert.Equal("game finished, black wins by resignation", resignResponse.GetStatus())
+	assert.Nil(DBConn.Where("uuid = ?", r.GetUuid()).First(&gameDb).Error)
+	assert.Equal(int(engine.BlackWinsResult), gameDb.Winner)
+	assert.Equal(int(engine.ResignationReason), gameDb.Reason)
+
+	// Case: the computer does not accept draws
+	r, err = server.StartGame(ctx, &StartGameRequest{Name: "somename", Color: Color_WHITE, Opponent: Opponent_COMPUTER})
+	assert.Nil(err)
+	_, err = server.OfferDraw(ctx, &OfferDrawRequest{Uuid: r.GetUuid()})
+	assert.EqualError(err, "the computer does not accept draws")
+}
+
+func TestServerTakeback(t *testing.T) {
+	assert := assert.New(t)
+	server := factoryServer()
+	ctx, cancel := createCtxMetadataUser(&User{AccessToken: "hereistoken123", Email: "some@mail.com"})
+	defer cancel()
+	ctxJoin, cancelJoin := createCtxMetadataUser(&User{AccessToken: "someothertoken", Email: "other@mail.com"})
+	defer cancelJoin()
+
+	r, err := server.StartGame(ctx, &StartGameRequest{Name: "somename", Color: Color_WHITE})
+	assert.Nil(err)
+	_, err = server.JoinGame(ctxJoin, &JoinGameRequest{Uuid: r.GetUuid()})
+	assert.Nil(err)
+	_, err = server.RequestTakeback(ctx, &RequestTakebackRequest{Uuid: r.GetUuid()})
+	assert.EqualError(err, "no movement to take back")
+	_, err = server.Move(ctx, &MoveRequest{Uuid: r.GetUuid(), San: "e4"})
+	assert.Nil(err)
+
+	_, err = server.RequestTakeback(ctx, &RequestTakebackRequest{Uuid: r.GetUuid()})
+	assert.Nil(err)
+	_, err = server.RequestTakeback(ctx, &RequestTakebackRequest{Uuid: r.GetUuid()})
+	assert.EqualError(err, "takeback already requested")
+	_, err = server.RespondTakeback(ctx, &RespondTakebackRequest{Uuid: r.GetUuid(), Accept: true})
+	assert.EqualError(err, "no takeback requested by your oponent")
+	takebackResponse, err := server.RespondTakeback(ctxJoin, &RespondTakebackRequest{Uuid: r.GetUuid(), Accept: true})
+	assert.Nil(err)
+	assert.Equal("white turn", takebackResponse.GetStatus())
+
+	var gameDb Game
+	assert.Nil(DBConn.Where("uuid = ?", r.GetUuid()).First(&gameDb).Error)
+	assert.False(gameDb.TakebackRequestedBy.Valid)
+	assert.Equal(uint(gameDb.WhitePlayerID.Int32), gameDb.Turn)
+	var movements int64
+	assert.Nil(DBConn.Model(&Movement{}).Where("game_id = ?", gameDb.ID).Count(&movements).Error)
+	assert.Equal(int64(0), movements)
+
+	// Case: the oponent reply is taken back too
+	for _, move := range []struct {
+		ctx context.Context
+		san string
+	}{{ctx, "d4"}, {ctxJoin, "d5"}, {ctx, "c4"}, {ctxJoin, "e6"}} {
+		_, err = server.Move(move.ctx, &MoveRequest{Uuid: r.GetUuid(), San: move.san})
+		assert.Nil(err)
+	}
+	_, err = server.RequestTakeback(ctx, &RequestTakebackRequest{Uuid: r.GetUuid()})
+	assert.Nil(err)
+	_, err = server.RespondTakeback(ctxJoin, &RespondTakebackRequest{Uuid: r.GetUuid()})
+	assert.Nil(err)
+	_, err = server.RespondTakeback(ctxJoin, &RespondTakebackRequest{Uuid: r.GetUuid(), Accept: true})
+	assert.EqualError(err, "no takeback requested by your oponent")
+	_, err = server.RequestTakeback(ctx, &RequestTakebackRequest{Uuid: r.GetUuid()})
+	assert.Nil(err)
+	takebackResponse, err = server.RespondTakeback(ctxJoin, &RespondTakebackRequest{Uuid: r.GetUuid(), Accept: true})
+	assert.Nil(err)
+	assert.Equal("white turn", takebackResponse.GetStatus())
+
+	// Case: moving cancels the takeback requested
+	_, err = server.RequestTakeback(ctxJoin, &RequestTakebackRequest{Uuid: r.GetUuid()})
+	assert.Nil(err)
+	_, err = server.Move(ctx, &MoveRequest{Uuid: r.GetUuid(), San: "Nf3"})
+	assert.Nil(err)
+	_, err = server.RespondTakeback(ctx, &RespondTakebackRequest{Uuid: r.GetUuid(), Accept: true})
+	assert.EqualError(err, "no takeback requested by your oponent")
+
+	exportResponse, err := server.ExportPGN(ctx, &ExportPGNRequest{Uuid: r.GetUuid()})
+	assert.Nil(err)
+	assert.Contains(exportResponse.GetPgn(), "1. d4 d5 2. Nf3 *\n")
+}
+
+func TestServerExportPGN(t *testing.T) {
+	assert := assert.New(t)
+	server := factoryServer()
+	ctx, cancel := createCtxMetadataUser(&User{AccessToken: "hereistoken123", Email: "some@mail.com", Name: "Luis"})
+	defer cancel()
+	r, err := server.StartGame(ctx, &StartGameRequest{
+		Name:  "somename",
+		Color: Color_WHITE,
+	})
+	assert.Nil(err)
+
+	exportResponse, err := server.ExportPGN(ctx, &ExportPGNRequest{Uuid: r.GetUuid()})
+	assert.Nil(err)
+	assert.Contains(exportResponse.GetPgn(), "[Event \"somename\"]\n")
+	assert.Contains(exportResponse.GetPgn(), "[White \"Luis\"]\n[Black \"?\"]\n[Result \"*\"]\n\n*\n")
+
+	// Case: movements are loaded from the database
+	ctxJoin, cancelJoin := createCtxMetadataUser(&User{AccessToken: "someothertoken", Email: "other@mail.com", Name: "Joel"})
+	defer cancelJoin()
+	_, err = server.JoinGame(ctxJoin, &JoinGameRequest{Uuid: r.GetUuid()})
+	assert.Nil(err)
+	for _, move := range []struct {
+		ctx context.Context
+		san string
+	}{{ctx, "e4"}, {ctxJoin, "e5"}, {ctx, "Ke2"}, {ctxJoin, "Nc6"}, {ctx, "Ke1"}} {
+		_, err = server.Move(move.ctx, &MoveRequest{Uuid: r.GetUuid(), San: move.san})
+		assert.Nil(err)
+	}
+	// castling is not allowed after the king moved
+	_, err = server.Move(ctxJoin, &MoveRequest{Uuid: r.GetUuid(), San: "Nf6"})
+	assert.Nil(err)
+	_, err = server.Move(ctx, &MoveRequest{Uuid: r.GetUuid(), San: "Nf3"})
+	assert.Nil(err)
+	_, err = server.Move(ctxJoin, &MoveRequest{Uuid: r.GetUuid(), San: "Bc5"})
+	assert.Nil(err)
+	_, err = server.Move(ctx, &MoveRequest{Uuid: r.GetUuid(), San: "Bc4"})
+	assert.Nil(err)
+	_, err = server.Move(ctxJoin, &MoveRequest{Uuid: r.GetUuid(), San: "d6"})
+	assert.Nil(err)
+	_, err = server.Move(ctx, &MoveRequest{Uuid: r.GetUuid(), San: "O-O"})
+	assert.EqualError(err, "illegal SAN move \"O-O\"")
+
+	exportResponse, err = server.ExportPGN(ctx, &ExportPGNRequest{Uuid: r.GetUuid()})
+	assert.Nil(err)
+	assert.Contains(exportResponse.GetPgn(), "[White \"Luis\"]\n[Black \"Joel\"]\n[Result \"*\"]\n\n1. e4 e5 2. Ke2 Nc6 3. Ke1 Nf6 4. Nf3 Bc5 5. Bc4 d6 *\n")
+}
+
+func TestServerComputer(t *testing.T) {
+	assert := assert.New(t)
+	server := factoryServer()
+	ctx, cancel := createCtxMetadataUser(&User{AccessToken: "computertoken", Email: "computer@mail.com", Name: "Luis"})
+	defer cancel()
+
+	_, err := server.StartGame(ctx, &StartGameRequest{Name: "somename", Color: Color_BLACK, Opponent: Opponent_COMPUTER, Level: 9})
+	assert.EqualError(err, "computer level must be between 1 and 8")
+
+	// Case: computer plays white, moving first
+	r, err := server.StartGame(ctx, &StartGameRequest{Name: "somename", Color: Color_BLACK, Opponent: Opponent_COMPUTER, Level: 1})
+	assert.Nil(err)
+	gameDb, whitePlayerDb, blackPlayerDb, err := loadGameAndPlayers(r.GetUuid())
+	assert.Nil(err)
+	assert.Equal(1, whitePlayerDb.Level)
+	assert.Equal(0, blackPlayerDb.Level)
+	assert.True(waitMovements(gameDb.ID, 1))
+
+	// Case: computer answers each movement
+	gameDb, _, _, err = loadGameAndPlayers(r.GetUuid())
+	assert.Nil(err)
+	gameEngine, err := loadEngineGameFromDbValues(gameDb, engine.Player{Color: engine.BlackColor})
+	assert.Nil(err)
+	m := gameEngine.LegalMoves()[0]
+	ctxMove, cancelMove := createCtxFromAccessToken("computertoken")
+	defer cancelMove()
+	_, err = server.Move(ctxMove, &MoveRequest{Uuid: r.GetUuid(), San: gameEngine.SAN(m)})
+	assert.Nil(err)
+	assert.True(waitMovements(gameDb.ID, 3))
+
+	exportResponse, err := server.ExportPGN(ctx, &ExportPGNRequest{Uuid: r.GetUuid()})
+	assert.Nil(err)
+	assert.Contains(exportResponse.GetPgn(), "[White \"Computer level 1\"]\n[Black \"Luis\"]\n")
+
+	// Case: the user can not move for the computer
+	assert.Nil(DBConn.Model(&Game{}).Where("id=?", gameDb.ID).Update("turn", whitePlayerDb.ID).Error)
+	_, err = server.Move(ctxMove, &MoveRequest{Uuid: r.GetUuid(), San: "a3"})
+	assert.EqualError(err, "Not your turn")
+}
+
+// waitMovements waits for the computer until game has n movements, returns false on timeout
+func waitMovements(gameID uint, n int64) bool {
+	for start := time.Now(); time.Since(start) < 5*time.Second; time.Sleep(50 * time.Millisecond) {
+		var count int64
+		DBConn.Model(&Movement{}).Where("game_id=?", gameID).Count(&count)
+		if count >= n {
+			return count == n
+		}
+	}
+	return false
 }
 
 func createCtxMetadataUser(u *User) (context.Context, context.CancelFunc) {
@@ -66,6 +66,107 @@ func (Color) EnumDescriptor() ([]byte, []int) {
 	return file_api_service_proto_rawDescGZIP(), []int{0}
 }
 
+type Promotion int32
+
+const (
+	Promotion_NO_PROMOTION Promotion = 0
+	Promotion_QUEEN        Promotion = 1
+	Promotion_ROOK         Promotion = 2
+	Promotion_BISHOP       Promotion = 3
+	Promotion_KNIGHT       Promotion = 4
+)
+
+// Enum value maps for Promotion.
+var (
+	Promotion_name = map[int32]string{
+		0: "NO_PROMOTION",
+		1: "QUEEN",
+		2: "ROOK",
+		3: "BISHOP",
+		4: "KNIGHT",
+	}
+	Promotion_value = map[string]int32{
+		"NO_PROMOTION": 0,
+		"QUEEN":        1,
+		"ROOK":         2,
+		"BISHOP":       3,
+		"KNIGHT":       4,
+	}
+)
+
+func (x Promotion) Enum() *Promotion {
+	p := new(Promotion)
+	*p = x
+	return p
+}
+
+func (x Promotion) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Promotion) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_service_proto_enumTypes[1].Descriptor()
+}
+
+func (Promotion) Type() protoreflect.EnumType {
+	return &file_api_service_proto_enumTypes[1]
+}
+
+func (x Promotion) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Promotion.Descriptor instead.
+func (Promotion) EnumDescriptor() ([]byte, []int) {
+	return file_api_service_proto_rawDescGZIP(), []int{1}
+}
+
+type Opponent int32
+
+const (
+	Opponent_HUMAN    Opponent = 0
+	Opponent_COMPUTER Opponent = 1
+)
+
+// Enum value maps for Opponent.
+var (
+	Opponent_name = map[int32]string{
+		0: "HUMAN",
+		1: "COMPUTER",
+	}
+	Opponent_value = map[string]int32{
+		"HUMAN":    0,
+		"COMPUTER": 1,
+	}
+)
+
+func (x Opponent) Enum() *Opponent {
+	p := new(Opponent)
+	*p = x
+	return p
+}
+
+func (x Opponent) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Opponent) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_service_proto_enumTypes[2].Descriptor()
+}
+
+func (Opponent) Type() protoreflect.EnumType {
+	return &file_api_service_proto_enumTypes[2]
+}
+
+func (x Opponent) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Opponent.Descriptor instead.
+func (Opponent) EnumDescriptor() ([]byte, []int) {
+	return file_api_service_proto_rawDescGZIP(), []int{2}
+}
+
 type StartGameRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -73,6 +174,12 @@ type StartGameRequest struct {
 
 	Name  string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	Color Color  `protobuf:"varint,2,opt,name=color,proto3,enum=Color" json:"color,omitempty"`
+	// opponent COMPUTER makes the server play the other color
+	Opponent Opponent `protobuf:"varint,3,opt,name=opponent,proto3,enum=Opponent" json:"opponent,omitempty"`
+	// level computer strength from 1 to 8, 0 means the default level
+	Level uint32 `protobuf:"varint,4,opt,name=level,proto3" json:"level,omitempty"`
+	// time_control clocks of the game, the game is not timed when not set
+	TimeControl *TimeControl `protobuf:"bytes,5,opt,name=time_control,json=timeControl,proto3" json:"time_control,omitempty"`
 }
 
 func (x *StartGameRequest) Reset() {
@@ -121,6 +228,91 @@ func (x *StartGameRequest) GetColor() Color {
 	return Color_BLACK
 }
 
+func (x *StartGameRequest) GetOpponent() Opponent {
+	if x != nil {
+		return x.Opponent
+	}
+	return Opponent_HUMAN
+}
+
+func (x *StartGameRequest) GetLevel() uint32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *StartGameRequest) GetTimeControl() *TimeControl {
+	if x != nil {
+		return x.TimeControl
+	}
+	return nil
+}
+
+// TimeControl either base time plus an increment per move, or days per move for correspondence games
+type TimeControl struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BaseSeconds      uint32 `protobuf:"varint,1,opt,name=base_seconds,json=baseSeconds,proto3" json:"base_seconds,omitempty"`
+	IncrementSeconds uint32 `protobuf:"varint,2,opt,name=increment_seconds,json=incrementSeconds,proto3" json:"increment_seconds,omitempty"`
+	DaysPerMove      uint32 `protobuf:"varint,3,opt,name=days_per_move,json=daysPerMove,proto3" json:"days_per_move,omitempty"`
+}
+
+func (x *TimeControl) Reset() {
+	*x = TimeControl{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_service_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TimeControl) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimeControl) ProtoMessage() {}
+
+func (x *TimeControl) ProtoReflect() protoreflect.Message {
+	mi := &file_api_service_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimeControl.ProtoReflect.Descriptor instead.
+func (*TimeControl) Descriptor() ([]byte, []int) {
+	return file_api_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TimeControl) GetBaseSeconds() uint32 {
+	if x != nil {
+		return x.BaseSeconds
+	}
+	return 0
+}
+
+func (x *TimeControl) GetIncrementSeconds() uint32 {
+	if x != nil {
+		return x.IncrementSeconds
+	}
+	return 0
+}
+
+func (x *TimeControl) GetDaysPerMove() uint32 {
+	if x != nil {
+		return x.DaysPerMove
+	}
+	return 0
+}
+
 type StartGameResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -132,7 +324,7 @@ type StartGameResponse struct {
 func (x *StartGameResponse) Reset() {
 	*x = StartGameResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_service_proto_msgTypes[1]
+		mi := &file_api_service_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -145,7 +337,7 @@ func (x *StartGameResponse) String() string {
 func (*StartGameResponse) ProtoMessage() {}
 
 func (x *StartGameResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_service_proto_msgTypes[1]
+	mi := &file_api_service_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -158,7 +350,7 @@ func (x *StartGameResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StartGameResponse.ProtoReflect.Descriptor instead.
 func (*StartGameResponse) Descriptor() ([]byte, []int) {
-	return file_api_service_proto_rawDescGZIP(), []int{1}
+	return file_api_service_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *StartGameResponse) GetUuid() string {
@@ -179,7 +371,7 @@ type JoinGameRequest struct {
 func (x *JoinGameRequest) Reset() {
 	*x = JoinGameRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_service_proto_msgTypes[2]
+		mi := &file_api_service_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -192,7 +384,7 @@ func (x *JoinGameRequest) String() string {
 func (*JoinGameRequest) ProtoMessage() {}
 
 func (x *JoinGameRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_service_proto_msgTypes[2]
+	mi := &file_api_service_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -205,7 +397,7 @@ func (x *JoinGameRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use JoinGameRequest.ProtoReflect.Descriptor instead.
 func (*JoinGameRequest) Descriptor() ([]byte, []int) {
-	return file_api_service_proto_rawDescGZIP(), []int{2}
+	return file_api_service_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *JoinGameRequest) GetUuid() string {
@@ -228,7 +420,7 @@ type JoinGameResponse struct {
 func (x *JoinGameResponse) Reset() {
 	*x = JoinGameResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_service_proto_msgTypes[3]
+		mi := &file_api_service_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -241,7 +433,7 @@ func (x *JoinGameResponse) String() string {
 func (*JoinGameResponse) ProtoMessage() {}
 
 func (x *JoinGameResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_service_proto_msgTypes[3]
+	mi := &file_api_service_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -254,7 +446,7 @@ func (x *JoinGameResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use JoinGameResponse.ProtoReflect.Descriptor instead.
 func (*JoinGameResponse) Descriptor() ([]byte, []int) {
-	return file_api_service_proto_rawDescGZIP(), []int{3}
+	return file_api_service_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *JoinGameResponse) GetUuid() string {
@@ -283,16 +475,21 @@ type MoveRequest struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Uuid       string `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
-	Color      Color  `protobuf:"varint,2,opt,name=color,proto3,enum=Color" json:"color,omitempty"`
-	FromSquare string `protobuf:"bytes,3,opt,name=from_square,json=fromSquare,proto3" json:"from_square,omitempty"`
-	ToSquare   string `protobuf:"bytes,4,opt,name=to_square,json=toSquare,proto3" json:"to_square,omitempty"`
+	Uuid       string    `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Color      Color     `protobuf:"varint,2,opt,name=color,proto3,enum=Color" json:"color,omitempty"`
+	FromSquare string    `protobuf:"bytes,3,opt,name=from_square,json=fromSquare,proto3" json:"from_square,omitempty"`
+	ToSquare   string    `protobuf:"bytes,4,opt,name=to_square,json=toSquare,proto3" json:"to_square,omitempty"`
+	Promotion  Promotion `protobuf:"varint,5,opt,name=promotion,proto3,enum=Promotion" json:"promotion,omitempty"`
+	// san movement in Standard Algebraic Notation, i.e. "Nf3", replaces from, to and promotion when set
+	San string `protobuf:"bytes,6,opt,name=san,proto3" json:"san,omitempty"`
+	// board_style style of the board in the response, drawn as a table when not set
+	BoardStyle *BoardStyle `protobuf:"bytes,7,opt,name=board_style,json=boardStyle,proto3" json:"board_style,omitempty"`
 }
 
 func (x *MoveRequest) Reset() {
 	*x = MoveRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_service_proto_msgTypes[4]
+		mi := &file_api_service_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -305,7 +502,7 @@ func (x *MoveRequest) String() string {
 func (*MoveRequest) ProtoMessage() {}
 
 func (x *MoveRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_service_proto_msgTypes[4]
+	mi := &file_api_service_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -318,7 +515,7 @@ func (x *MoveRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MoveRequest.ProtoReflect.Descriptor instead.
 func (*MoveRequest) Descriptor() ([]byte, []int) {
-	return file_api_service_proto_rawDescGZIP(), []int{4}
+	return file_api_service_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *MoveRequest) GetUuid() string {
@@ -349,6 +546,99 @@ func (x *MoveRequest) GetToSquare() string {
 	return ""
 }
 
+func (x *MoveRequest) GetPromotion() Promotion {
+	if x != nil {
+		return x.Promotion
+	}
+	return Promotion_NO_PROMOTION
+}
+
+func (x *MoveRequest) GetSan() string {
+	if x != nil {
+		return x.San
+	}
+	return ""
+}
+
+func (x *MoveRequest) GetBoardStyle() *BoardStyle {
+	if x != nil {
+		return x.BoardStyle
+	}
+	return nil
+}
+
+// BoardStyle how a board is drawn, ASCII letters from white side unless set
+type BoardStyle struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Unicode     bool `protobuf:"varint,1,opt,name=unicode,proto3" json:"unicode,omitempty"`
+	Color       bool `protobuf:"varint,2,opt,name=color,proto3" json:"color,omitempty"`
+	Coordinates bool `protobuf:"varint,3,opt,name=coordinates,proto3" json:"coordinates,omitempty"`
+	Flip        bool `protobuf:"varint,4,opt,name=flip,proto3" json:"flip,omitempty"`
+}
+
+func (x *BoardStyle) Reset() {
+	*x = BoardStyle{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_service_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BoardStyle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BoardStyle) ProtoMessage() {}
+
+func (x *BoardStyle) ProtoReflect() protoreflect.Message {
+	mi := &file_api_service_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BoardStyle.ProtoReflect.Descriptor instead.
+func (*BoardStyle) Descriptor() ([]byte, []int) {
+	return file_api_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *BoardStyle) GetUnicode() bool {
+	if x != nil {
+		return x.Unicode
+	}
+	return false
+}
+
+func (x *BoardStyle) GetColor() bool {
+	if x != nil {
+		return x.Color
+	}
+	return false
+}
+
+func (x *BoardStyle) GetCoordinates() bool {
+	if x != nil {
+		return x.Coordinates
+	}
+	return false
+}
+
+func (x *BoardStyle) GetFlip() bool {
+	if x != nil {
+		return x.Flip
+	}
+	return false
+}
+
 type MoveResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -357,12 +647,13 @@ type MoveResponse struct {
 	StatusCode string `protobuf:"bytes,1,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
 	Error      string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
 	Board      string `protobuf:"bytes,3,opt,name=board,proto3" json:"board,omitempty"`
+	San        string `protobuf:"bytes,4,opt,name=san,proto3" json:"san,omitempty"`
 }
 
 func (x *MoveResponse) Reset() {
 	*x = MoveResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_service_proto_msgTypes[5]
+		mi := &file_api_service_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -375,7 +666,7 @@ func (x *MoveResponse) String() string {
 func (*MoveResponse) ProtoMessage() {}
 
 func (x *MoveResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_service_proto_msgTypes[5]
+	mi := &file_api_service_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -388,7 +679,7 @@ func (x *MoveResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MoveResponse.ProtoReflect.Descriptor instead.
 func (*MoveResponse) Descriptor() ([]byte, []int) {
-	return file_api_service_proto_rawDescGZIP(), []int{5}
+	return file_api_service_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *MoveResponse) GetStatusCode() string {
@@ -412,18 +703,27 @@ func (x *MoveResponse) GetBoard() string {
 	return ""
 }
 
+func (x *MoveResponse) GetSan() string {
+	if x != nil {
+		return x.San
+	}
+	return ""
+}
+
 type WatchRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	Uuid string `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	// board_style style of the boards in the responses, drawn as a table when not set
+	BoardStyle *BoardStyle `protobuf:"bytes,2,opt,name=board_style,json=boardStyle,proto3" json:"board_style,omitempty"`
 }
 
 func (x *WatchRequest) Reset() {
 	*x = WatchRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_service_proto_msgTypes[6]
+		mi := &file_api_service_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -436,7 +736,7 @@ func (x *WatchRequest) String() string {
 func (*WatchRequest) ProtoMessage() {}
 
 func (x *WatchRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_service_proto_msgTypes[6]
+	mi := &file_api_service_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -449,7 +749,7 @@ func (x *WatchRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WatchRequest.ProtoReflect.Descriptor instead.
 func (*WatchRequest) Descriptor() ([]byte, []int) {
-	return file_api_service_proto_rawDescGZIP(), []int{6}
+	return file_api_service_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *WatchRequest) GetUuid() string {
@@ -459,20 +759,43 @@ func (x *WatchRequest) GetUuid() string {
 	return ""
 }
 
+func (x *WatchRequest) GetBoardStyle() *BoardStyle {
+	if x != nil {
+		return x.BoardStyle
+	}
+	return nil
+}
+
 type WatchResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Turn   string `protobuf:"bytes,1,opt,name=turn,proto3" json:"turn,omitempty"`
-	Board  string `protobuf:"bytes,2,opt,name=board,proto3" json:"board,omitempty"`
-	Status string `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Turn     string `protobuf:"bytes,1,opt,name=turn,proto3" json:"turn,omitempty"`
+	Board    string `protobuf:"bytes,2,opt,name=board,proto3" json:"board,omitempty"`
+	Status   string `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	LastMove string `protobuf:"bytes,4,opt,name=last_move,json=lastMove,proto3" json:"last_move,omitempty"`
+	// fen current position, empty when the update does not change it
+	Fen string `protobuf:"bytes,5,opt,name=fen,proto3" json:"fen,omitempty"`
+	// moves every movement made in SAN
+	Moves []string `protobuf:"bytes,6,rep,name=moves,proto3" json:"moves,omitempty"`
+	// timed true when the game is played with clocks
+	Timed bool `protobuf:"varint,7,opt,name=timed,proto3" json:"timed,omitempty"`
+	// white_time_ms and black_time_ms time left of each player, in milliseconds
+	WhiteTimeMs int64 `protobuf:"varint,8,opt,name=white_time_ms,json=whiteTimeMs,proto3" json:"white_time_ms,omitempty"`
+	BlackTimeMs int64 `protobuf:"varint,9,opt,name=black_time_ms,json=blackTimeMs,proto3" json:"black_time_ms,omitempty"`
+	// draw_offer true while the player of draw_offer_color offers a draw
+	DrawOffer      bool  `protobuf:"varint,10,opt,name=draw_offer,json=drawOffer,proto3" json:"draw_offer,omitempty"`
+	DrawOfferColor Color `protobuf:"varint,11,opt,name=draw_offer_color,json=drawOfferColor,proto3,enum=Color" json:"draw_offer_color,omitempty"`
+	// takeback_request true while the player of takeback_request_color asks to take back its last movement
+	TakebackRequest      bool  `protobuf:"varint,12,opt,name=takeback_request,json=takebackRequest,proto3" json:"takeback_request,omitempty"`
+	TakebackRequestColor Color `protobuf:"varint,13,opt,name=takeback_request_color,json=takebackRequestColor,proto3,enum=Color" json:"takeback_request_color,omitempty"`
 }
 
 func (x *WatchResponse) Reset() {
 	*x = WatchResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_service_proto_msgTypes[7]
+		mi := &file_api_service_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -485,7 +808,7 @@ func (x *WatchResponse) String() string {
 func (*WatchResponse) ProtoMessage() {}
 
 func (x *WatchResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_service_proto_msgTypes[7]
+	mi := &file_api_service_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -498,7 +821,7 @@ func (x *WatchResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WatchResponse.ProtoReflect.Descriptor instead.
 func (*WatchResponse) Descriptor() ([]byte, []int) {
-	return file_api_service_proto_rawDescGZIP(), []int{7}
+	return file_api_service_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *WatchResponse) GetTurn() string {
@@ -522,120 +845,927 @@ func (x *WatchResponse) GetStatus() string {
 	return ""
 }
 
-var File_api_service_proto protoreflect.FileDescriptor
+func (x *WatchResponse) GetLastMove() string {
+	if x != nil {
+		return x.LastMove
+	}
+	return ""
+}
 
-var file_api_service_proto_rawDesc = []byte{
-	0x0a, 0x11, 0x61, 0x70, 0x69, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x22, 0x44, 0x0a, 0x10, 0x53, 0x74, 0x61, 0x72, 0x74, 0x47, 0x61, 0x6d, 0x65,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x05, 0x63,
-	0x6f, 0x6c, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x06, 0x2e, 0x43, 0x6f, 0x6c,
-	0x6f, 0x72, 0x52, 0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x22, 0x27, 0x0a, 0x11, 0x53, 0x74, 0x61,
-	0x72, 0x74, 0x47, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12,
-	0x0a, 0x04, 0x75, 0x75, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x75,
-	0x69, 0x64, 0x22, 0x25, 0x0a, 0x0f, 0x4a, 0x6f, 0x69, 0x6e, 0x47, 0x61, 0x6d, 0x65, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x75, 0x69, 0x64, 0x22, 0x58, 0x0a, 0x10, 0x4a, 0x6f, 0x69,
-	0x6e, 0x47, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a,
-	0x04, 0x75, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x75, 0x69,
-	0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x0e, 0x32, 0x06, 0x2e, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x52, 0x05, 0x63, 0x6f,
-	0x6c, 0x6f, 0x72, 0x22, 0x7d, 0x0a, 0x0b, 0x4d, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x04, 0x75, 0x75, 0x69, 0x64, 0x12, 0x1c, 0x0a, 0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x06, 0x2e, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x52, 0x05, 0x63,
-	0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x73, 0x71, 0x75,
-	0x61, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x66, 0x72, 0x6f, 0x6d, 0x53,
-	0x71, 0x75, 0x61, 0x72, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x6f, 0x5f, 0x73, 0x71, 0x75, 0x61,
-	0x72, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x6f, 0x53, 0x71, 0x75, 0x61,
-	0x72, 0x65, 0x22, 0x5b, 0x0a, 0x0c, 0x4d, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x63, 0x6f, 0x64,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43,
-	0x6f, 0x64, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x6f, 0x61,
-	0x72, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x22,
-	0x22, 0x0a, 0x0c, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x12, 0x0a, 0x04, 0x75, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75,
-	0x75, 0x69, 0x64, 0x22, 0x51, 0x0a, 0x0d, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x75, 0x72, 0x6e, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x04, 0x74, 0x75, 0x72, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x6f, 0x61, 0x72,
-	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x12, 0x16,
-	0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
-	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x2a, 0x1d, 0x0a, 0x05, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x12,
-	0x09, 0x0a, 0x05, 0x42, 0x4c, 0x41, 0x43, 0x4b, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x57, 0x48,
-	0x49, 0x54, 0x45, 0x10, 0x01, 0x32, 0xc2, 0x01, 0x0a, 0x0c, 0x43, 0x68, 0x65, 0x73, 0x73, 0x53,
-	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x32, 0x0a, 0x09, 0x53, 0x74, 0x61, 0x72, 0x74, 0x47,
-	0x61, 0x6d, 0x65, 0x12, 0x11, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x47, 0x61, 0x6d, 0x65, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x47, 0x61,
-	0x6d, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x08, 0x4a, 0x6f,
-	0x69, 0x6e, 0x47, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x2e, 0x4a, 0x6f, 0x69, 0x6e, 0x47, 0x61, 0x6d,
-	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x4a, 0x6f, 0x69, 0x6e, 0x47,
-	0x61, 0x6d, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x23, 0x0a, 0x04, 0x4d,
-	0x6f, 0x76, 0x65, 0x12, 0x0c, 0x2e, 0x4d, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x0d, 0x2e, 0x4d, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x28, 0x0a, 0x05, 0x57, 0x61, 0x74, 0x63, 0x68, 0x12, 0x0d, 0x2e, 0x57, 0x61, 0x74, 0x63,
-	0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0e, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x42, 0x1e, 0x5a, 0x1c, 0x67, 0x69,
-	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x64, 0x75, 0x6d, 0x62, 0x6f, 0x67, 0x6f,
-	0x2f, 0x63, 0x68, 0x65, 0x73, 0x73, 0x2f, 0x61, 0x70, 0x69, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x33,
+func (x *WatchResponse) GetFen() string {
+	if x != nil {
+		return x.Fen
+	}
+	return ""
 }
 
-var (
-	file_api_service_proto_rawDescOnce sync.Once
-	file_api_service_proto_rawDescData = file_api_service_proto_rawDesc
-)
+func (x *WatchResponse) GetMoves() []string {
+	if x != nil {
+		return x.Moves
+	}
+	return nil
+}
 
-func file_api_service_proto_rawDescGZIP() []byte {
-	file_api_service_proto_rawDescOnce.Do(func() {
-		file_api_service_proto_rawDescData = protoimpl.X.CompressGZIP(file_api_service_proto_rawDescData)
-	})
-	return file_api_service_proto_rawDescData
+func (x *WatchResponse) GetTimed() bool {
+	if x != nil {
+		return x.Timed
+	}
+	return false
 }
 
-var file_api_service_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_api_service_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
-var file_api_service_proto_goTypes = []interface{}{
-	(Color)(0),                // 0: Color
-	(*StartGameRequest)(nil),  // 1: StartGameRequest
-	(*StartGameResponse)(nil), // 2: StartGameResponse
-	(*JoinGameRequest)(nil),   // 3: JoinGameRequest
-	(*JoinGameResponse)(nil),  // 4: JoinGameResponse
-	(*MoveRequest)(nil),       // 5: MoveRequest
-	(*MoveResponse)(nil),      // 6: MoveResponse
-	(*WatchRequest)(nil),      // 7: WatchRequest
-	(*WatchResponse)(nil),     // 8: WatchResponse
+func (x *WatchResponse) GetWhiteTimeMs() int64 {
+	if x != nil {
+		return x.WhiteTimeMs
+	}
+	return 0
 }
-var file_api_service_proto_depIdxs = []int32{
-	0, // 0: StartGameRequest.color:type_name -> Color
-	0, // 1: JoinGameResponse.color:type_name -> Color
-	0, // 2: MoveRequest.color:type_name -> Color
-	1, // 3: ChessService.StartGame:input_type -> StartGameRequest
-	3, // 4: ChessService.JoinGame:input_type -> JoinGameRequest
-	5, // 5: ChessService.Move:input_type -> MoveRequest
-	7, // 6: ChessService.Watch:input_type -> WatchRequest
-	2, // 7: ChessService.StartGame:output_type -> StartGameResponse
-	4, // 8: ChessService.JoinGame:output_type -> JoinGameResponse
-	6, // 9: ChessService.Move:output_type -> MoveResponse
-	8, // 10: ChessService.Watch:output_type -> WatchResponse
-	7, // [7:11] is the sub-list for method output_type
-	3, // [3:7] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+
+func (x *WatchResponse) GetBlackTimeMs() int64 {
+	if x != nil {
+		return x.BlackTimeMs
+	}
+	return 0
 }
 
-func init() { file_api_service_proto_init() }
-func file_api_service_proto_init() {
-	if File_api_service_proto != nil {
-		return
+func (x *WatchResponse) GetDrawOffer() bool {
+	if x != nil {
+		return x.DrawOffer
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_api_service_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StartGameRequest); i {
-			case 0:
-				return &v.state
+	return false
+}
+
+func (x *WatchResponse) GetDrawOfferColor() Color {
+	if x != nil {
+		return x.DrawOfferColor
+	}
+	return Color_BLACK
+}
+
+func (x *WatchResponse) GetTakebackRequest() bool {
+	if x != nil {
+		return x.TakebackRequest
+	}
+	return false
+}
+
+func (x *WatchResponse) GetTakebackRequestColor() Color {
+	if x != nil {
+		return x.TakebackRequestColor
+	}
+	return Color_BLACK
+}
+
+type ExportPGNRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Uuid string `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+}
+
+func (x *ExportPGNRequest) Reset() {
+	*x = ExportPGNRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_service_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportPGNRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportPGNRequest) ProtoMessage() {}
+
+func (x *ExportPGNRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_service_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportPGNRequest.ProtoReflect.Descriptor instead.
+func (*ExportPGNRequest) Descriptor() ([]byte, []int) {
+	return file_api_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ExportPGNRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+type ExportPGNResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pgn string `protobuf:"bytes,1,opt,name=pgn,proto3" json:"pgn,omitempty"`
+}
+
+func (x *ExportPGNResponse) Reset() {
+	*x = ExportPGNResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_service_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportPGNResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportPGNResponse) ProtoMessage() {}
+
+func (x *ExportPGNResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_service_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportPGNResponse.ProtoReflect.Descriptor instead.
+func (*ExportPGNResponse) Descriptor() ([]byte, []int) {
+	return file_api_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ExportPGNResponse) GetPgn() string {
+	if x != nil {
+		return x.Pgn
+	}
+	return ""
+}
+
+type ResignRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Uuid string `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+}
+
+func (x *ResignRequest) Reset() {
+	*x = ResignRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_service_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResignRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResignRequest) ProtoMessage() {}
+
+func (x *ResignRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_service_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResignRequest.ProtoReflect.Descriptor instead.
+func (*ResignRequest) Descriptor() ([]byte, []int) {
+	return file_api_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ResignRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+type ResignResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status string `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+}
+
+func (x *ResignResponse) Reset() {
+	*x = ResignResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_service_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResignResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResignResponse) ProtoMessage() {}
+
+func (x *ResignResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_service_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResignResponse.ProtoReflect.Descriptor instead.
+func (*ResignResponse) Descriptor() ([]byte, []int) {
+	return file_api_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ResignResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type OfferDrawRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Uuid string `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+}
+
+func (x *OfferDrawRequest) Reset() {
+	*x = OfferDrawRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_service_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OfferDrawRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OfferDrawRequest) ProtoMessage() {}
+
+func (x *OfferDrawRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_service_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OfferDrawRequest.ProtoReflect.Descriptor instead.
+func (*OfferDrawRequest) Descriptor() ([]byte, []int) {
+	return file_api_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *OfferDrawRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+type OfferDrawResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status string `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+}
+
+func (x *OfferDrawResponse) Reset() {
+	*x = OfferDrawResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_service_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OfferDrawResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OfferDrawResponse) ProtoMessage() {}
+
+func (x *OfferDrawResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_service_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OfferDrawResponse.ProtoReflect.Descriptor instead.
+func (*OfferDrawResponse) Descriptor() ([]byte, []int) {
+	return file_api_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *OfferDrawResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+// RespondDrawRequest accepts or declines the draw offered by the oponent
+type RespondDrawRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Uuid   string `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Accept bool   `protobuf:"varint,2,opt,name=accept,proto3" json:"accept,omitempty"`
+}
+
+func (x *RespondDrawRequest) Reset() {
+	*x = RespondDrawRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_service_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RespondDrawRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RespondDrawRequest) ProtoMessage() {}
+
+func (x *RespondDrawRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_service_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RespondDrawRequest.ProtoReflect.Descriptor instead.
+func (*RespondDrawRequest) Descriptor() ([]byte, []int) {
+	return file_api_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *RespondDrawRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *RespondDrawRequest) GetAccept() bool {
+	if x != nil {
+		return x.Accept
+	}
+	return false
+}
+
+type RespondDrawResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status string `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+}
+
+func (x *RespondDrawResponse) Reset() {
+	*x = RespondDrawResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_service_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RespondDrawResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RespondDrawResponse) ProtoMessage() {}
+
+func (x *RespondDrawResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_service_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RespondDrawResponse.ProtoReflect.Descriptor instead.
+func (*RespondDrawResponse) Descriptor() ([]byte, []int) {
+	return file_api_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RespondDrawResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+// RequestTakebackRequest asks the oponent to undo the last movement of the user, along with the oponent reply to it
+type RequestTakebackRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Uuid string `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+}
+
+func (x *RequestTakebackRequest) Reset() {
+	*x = RequestTakebackRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_service_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RequestTakebackRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestTakebackRequest) ProtoMessage() {}
+
+func (x *RequestTakebackRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_service_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestTakebackRequest.ProtoReflect.Descriptor instead.
+func (*RequestTakebackRequest) Descriptor() ([]byte, []int) {
+	return file_api_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *RequestTakebackRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+type RequestTakebackResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status string `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+}
+
+func (x *RequestTakebackResponse) Reset() {
+	*x = RequestTakebackResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_service_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RequestTakebackResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestTakebackResponse) ProtoMessage() {}
+
+func (x *RequestTakebackResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_service_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestTakebackResponse.ProtoReflect.Descriptor instead.
+func (*RequestTakebackResponse) Descriptor() ([]byte, []int) {
+	return file_api_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *RequestTakebackResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+// RespondTakebackRequest accepts or declines the takeback requested by the oponent
+type RespondTakebackRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Uuid   string `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Accept bool   `protobuf:"varint,2,opt,name=accept,proto3" json:"accept,omitempty"`
+}
+
+func (x *RespondTakebackRequest) Reset() {
+	*x = RespondTakebackRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_service_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RespondTakebackRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RespondTakebackRequest) ProtoMessage() {}
+
+func (x *RespondTakebackRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_service_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RespondTakebackRequest.ProtoReflect.Descriptor instead.
+func (*RespondTakebackRequest) Descriptor() ([]byte, []int) {
+	return file_api_service_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *RespondTakebackRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *RespondTakebackRequest) GetAccept() bool {
+	if x != nil {
+		return x.Accept
+	}
+	return false
+}
+
+type RespondTakebackResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status string `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+}
+
+func (x *RespondTakebackResponse) Reset() {
+	*x = RespondTakebackResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_service_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RespondTakebackResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RespondTakebackResponse) ProtoMessage() {}
+
+func (x *RespondTakebackResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_service_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RespondTakebackResponse.ProtoReflect.Descriptor instead.
+func (*RespondTakebackResponse) Descriptor() ([]byte, []int) {
+	return file_api_service_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *RespondTakebackResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+var File_api_service_proto protoreflect.FileDescriptor
+
+var file_api_service_proto_rawDesc = []byte{
+	0x0a, 0x11, 0x61, 0x70, 0x69, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x22, 0xb2, 0x01, 0x0a, 0x10, 0x53, 0x74, 0x61, 0x72, 0x74, 0x47, 0x61, 0x6d,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x05,
+	0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x06, 0x2e, 0x43, 0x6f,
+	0x6c, 0x6f, 0x72, 0x52, 0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x25, 0x0a, 0x08, 0x6f, 0x70,
+	0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x09, 0x2e, 0x4f,
+	0x70, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x52, 0x08, 0x6f, 0x70, 0x70, 0x6f, 0x6e, 0x65, 0x6e,
+	0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x2f, 0x0a, 0x0c, 0x74, 0x69, 0x6d, 0x65, 0x5f,
+	0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e,
+	0x54, 0x69, 0x6d, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x52, 0x0b, 0x74, 0x69, 0x6d,
+	0x65, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x22, 0x81, 0x01, 0x0a, 0x0b, 0x54, 0x69, 0x6d,
+	0x65, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x61, 0x73, 0x65,
+	0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b,
+	0x62, 0x61, 0x73, 0x65, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x69,
+	0x6e, 0x63, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x10, 0x69, 0x6e, 0x63, 0x72, 0x65, 0x6d, 0x65, 0x6e,
+	0x74, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x22, 0x0a, 0x0d, 0x64, 0x61, 0x79, 0x73,
+	0x5f, 0x70, 0x65, 0x72, 0x5f, 0x6d, 0x6f, 0x76, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x0b, 0x64, 0x61, 0x79, 0x73, 0x50, 0x65, 0x72, 0x4d, 0x6f, 0x76, 0x65, 0x22, 0x27, 0x0a, 0x11,
+	0x53, 0x74, 0x61, 0x72, 0x74, 0x47, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x75, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x75, 0x75, 0x69, 0x64, 0x22, 0x25, 0x0a, 0x0f, 0x4a, 0x6f, 0x69, 0x6e, 0x47, 0x61, 0x6d,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x75, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x75, 0x69, 0x64, 0x22, 0x58, 0x0a, 0x10,
+	0x4a, 0x6f, 0x69, 0x6e, 0x47, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x75, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x75, 0x75, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x05, 0x63, 0x6f, 0x6c, 0x6f,
+	0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x06, 0x2e, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x52,
+	0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x22, 0xe7, 0x01, 0x0a, 0x0b, 0x4d, 0x6f, 0x76, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x75, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x75, 0x69, 0x64, 0x12, 0x1c, 0x0a, 0x05, 0x63, 0x6f,
+	0x6c, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x06, 0x2e, 0x43, 0x6f, 0x6c, 0x6f,
+	0x72, 0x52, 0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x72, 0x6f, 0x6d,
+	0x5f, 0x73, 0x71, 0x75, 0x61, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x66,
+	0x72, 0x6f, 0x6d, 0x53, 0x71, 0x75, 0x61, 0x72, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x6f, 0x5f,
+	0x73, 0x71, 0x75, 0x61, 0x72, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x6f,
+	0x53, 0x71, 0x75, 0x61, 0x72, 0x65, 0x12, 0x28, 0x0a, 0x09, 0x70, 0x72, 0x6f, 0x6d, 0x6f, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0a, 0x2e, 0x50, 0x72, 0x6f, 0x6d,
+	0x6f, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x6d, 0x6f, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x10, 0x0a, 0x03, 0x73, 0x61, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73,
+	0x61, 0x6e, 0x12, 0x2c, 0x0a, 0x0b, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x5f, 0x73, 0x74, 0x79, 0x6c,
+	0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x53,
+	0x74, 0x79, 0x6c, 0x65, 0x52, 0x0a, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x53, 0x74, 0x79, 0x6c, 0x65,
+	0x22, 0x72, 0x0a, 0x0a, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x53, 0x74, 0x79, 0x6c, 0x65, 0x12, 0x18,
+	0x0a, 0x07, 0x75, 0x6e, 0x69, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x07, 0x75, 0x6e, 0x69, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x6c, 0x6f,
+	0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x20,
+	0x0a, 0x0b, 0x63, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0b, 0x63, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x73,
+	0x12, 0x12, 0x0a, 0x04, 0x66, 0x6c, 0x69, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04,
+	0x66, 0x6c, 0x69, 0x70, 0x22, 0x6d, 0x0a, 0x0c, 0x4d, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x63,
+	0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x62,
+	0x6f, 0x61, 0x72, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x62, 0x6f, 0x61, 0x72,
+	0x64, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x61, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x73, 0x61, 0x6e, 0x22, 0x50, 0x0a, 0x0c, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x75, 0x75, 0x69, 0x64, 0x12, 0x2c, 0x0a, 0x0b, 0x62, 0x6f, 0x61, 0x72, 0x64,
+	0x5f, 0x73, 0x74, 0x79, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x42,
+	0x6f, 0x61, 0x72, 0x64, 0x53, 0x74, 0x79, 0x6c, 0x65, 0x52, 0x0a, 0x62, 0x6f, 0x61, 0x72, 0x64,
+	0x53, 0x74, 0x79, 0x6c, 0x65, 0x22, 0xae, 0x03, 0x0a, 0x0d, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x75, 0x72, 0x6e, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x75, 0x72, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x62,
+	0x6f, 0x61, 0x72, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x62, 0x6f, 0x61, 0x72,
+	0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x61, 0x73,
+	0x74, 0x5f, 0x6d, 0x6f, 0x76, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x61,
+	0x73, 0x74, 0x4d, 0x6f, 0x76, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x66, 0x65, 0x6e, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x66, 0x65, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x6f, 0x76, 0x65,
+	0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x6d, 0x6f, 0x76, 0x65, 0x73, 0x12, 0x14,
+	0x0a, 0x05, 0x74, 0x69, 0x6d, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x74,
+	0x69, 0x6d, 0x65, 0x64, 0x12, 0x22, 0x0a, 0x0d, 0x77, 0x68, 0x69, 0x74, 0x65, 0x5f, 0x74, 0x69,
+	0x6d, 0x65, 0x5f, 0x6d, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x77, 0x68, 0x69,
+	0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x4d, 0x73, 0x12, 0x22, 0x0a, 0x0d, 0x62, 0x6c, 0x61, 0x63,
+	0x6b, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x6d, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0b, 0x62, 0x6c, 0x61, 0x63, 0x6b, 0x54, 0x69, 0x6d, 0x65, 0x4d, 0x73, 0x12, 0x1d, 0x0a, 0x0a,
+	0x64, 0x72, 0x61, 0x77, 0x5f, 0x6f, 0x66, 0x66, 0x65, 0x72, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x09, 0x64, 0x72, 0x61, 0x77, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x12, 0x30, 0x0a, 0x10, 0x64,
+	0x72, 0x61, 0x77, 0x5f, 0x6f, 0x66, 0x66, 0x65, 0x72, 0x5f, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x18,
+	0x0b, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x06, 0x2e, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x52, 0x0e, 0x64,
+	0x72, 0x61, 0x77, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x29, 0x0a,
+	0x10, 0x74, 0x61, 0x6b, 0x65, 0x62, 0x61, 0x63, 0x6b, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x74, 0x61, 0x6b, 0x65, 0x62, 0x61, 0x63,
+	0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3c, 0x0a, 0x16, 0x74, 0x61, 0x6b, 0x65,
+	0x62, 0x61, 0x63, 0x6b, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x63, 0x6f, 0x6c,
+	0x6f, 0x72, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x06, 0x2e, 0x43, 0x6f, 0x6c, 0x6f, 0x72,
+	0x52, 0x14, 0x74, 0x61, 0x6b, 0x65, 0x62, 0x61, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x22, 0x26, 0x0a, 0x10, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74,
+	0x50, 0x47, 0x4e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x75,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x75, 0x69, 0x64, 0x22, 0x25,
+	0x0a, 0x11, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x47, 0x4e, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x67, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x70, 0x67, 0x6e, 0x22, 0x23, 0x0a, 0x0d, 0x52, 0x65, 0x73, 0x69, 0x67, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x75, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x75, 0x69, 0x64, 0x22, 0x28, 0x0a, 0x0e, 0x52, 0x65,
+	0x73, 0x69, 0x67, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x22, 0x26, 0x0a, 0x10, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x44, 0x72, 0x61,
+	0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x75, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x75, 0x69, 0x64, 0x22, 0x2b, 0x0a, 0x11,
+	0x4f, 0x66, 0x66, 0x65, 0x72, 0x44, 0x72, 0x61, 0x77, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x40, 0x0a, 0x12, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x64, 0x44, 0x72, 0x61, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x12, 0x0a, 0x04, 0x75, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75,
+	0x75, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x06, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x22, 0x2d, 0x0a, 0x13, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x64, 0x44, 0x72, 0x61, 0x77, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x2c, 0x0a, 0x16, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x54, 0x61, 0x6b, 0x65, 0x62, 0x61, 0x63, 0x6b, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x75, 0x75, 0x69, 0x64, 0x22, 0x31, 0x0a, 0x17, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x54, 0x61, 0x6b, 0x65, 0x62, 0x61, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x44, 0x0a, 0x16, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x64, 0x54, 0x61, 0x6b, 0x65, 0x62, 0x61, 0x63, 0x6b, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x75, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63, 0x63,
+	0x65, 0x70, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x61, 0x63, 0x63, 0x65, 0x70,
+	0x74, 0x22, 0x31, 0x0a, 0x17, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x64, 0x54, 0x61, 0x6b, 0x65,
+	0x62, 0x61, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x2a, 0x1d, 0x0a, 0x05, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x09, 0x0a,
+	0x05, 0x42, 0x4c, 0x41, 0x43, 0x4b, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x57, 0x48, 0x49, 0x54,
+	0x45, 0x10, 0x01, 0x2a, 0x4a, 0x0a, 0x09, 0x50, 0x72, 0x6f, 0x6d, 0x6f, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x10, 0x0a, 0x0c, 0x4e, 0x4f, 0x5f, 0x50, 0x52, 0x4f, 0x4d, 0x4f, 0x54, 0x49, 0x4f, 0x4e,
+	0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x51, 0x55, 0x45, 0x45, 0x4e, 0x10, 0x01, 0x12, 0x08, 0x0a,
+	0x04, 0x52, 0x4f, 0x4f, 0x4b, 0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06, 0x42, 0x49, 0x53, 0x48, 0x4f,
+	0x50, 0x10, 0x03, 0x12, 0x0a, 0x0a, 0x06, 0x4b, 0x4e, 0x49, 0x47, 0x48, 0x54, 0x10, 0x04, 0x2a,
+	0x23, 0x0a, 0x08, 0x4f, 0x70, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x12, 0x09, 0x0a, 0x05, 0x48,
+	0x55, 0x4d, 0x41, 0x4e, 0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x43, 0x4f, 0x4d, 0x50, 0x55, 0x54,
+	0x45, 0x52, 0x10, 0x01, 0x32, 0x9b, 0x04, 0x0a, 0x0c, 0x43, 0x68, 0x65, 0x73, 0x73, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x32, 0x0a, 0x09, 0x53, 0x74, 0x61, 0x72, 0x74, 0x47, 0x61,
+	0x6d, 0x65, 0x12, 0x11, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x47, 0x61, 0x6d, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x47, 0x61, 0x6d,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x08, 0x4a, 0x6f, 0x69,
+	0x6e, 0x47, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x2e, 0x4a, 0x6f, 0x69, 0x6e, 0x47, 0x61, 0x6d, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x4a, 0x6f, 0x69, 0x6e, 0x47, 0x61,
+	0x6d, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x23, 0x0a, 0x04, 0x4d, 0x6f,
+	0x76, 0x65, 0x12, 0x0c, 0x2e, 0x4d, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x0d, 0x2e, 0x4d, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x28, 0x0a, 0x05, 0x57, 0x61, 0x74, 0x63, 0x68, 0x12, 0x0d, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0e, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x32, 0x0a, 0x09, 0x45, 0x78, 0x70,
+	0x6f, 0x72, 0x74, 0x50, 0x47, 0x4e, 0x12, 0x11, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x50,
+	0x47, 0x4e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x45, 0x78, 0x70, 0x6f,
+	0x72, 0x74, 0x50, 0x47, 0x4e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x29, 0x0a,
+	0x06, 0x52, 0x65, 0x73, 0x69, 0x67, 0x6e, 0x12, 0x0e, 0x2e, 0x52, 0x65, 0x73, 0x69, 0x67, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0f, 0x2e, 0x52, 0x65, 0x73, 0x69, 0x67, 0x6e,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x32, 0x0a, 0x09, 0x4f, 0x66, 0x66, 0x65,
+	0x72, 0x44, 0x72, 0x61, 0x77, 0x12, 0x11, 0x2e, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x44, 0x72, 0x61,
+	0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x4f, 0x66, 0x66, 0x65, 0x72,
+	0x44, 0x72, 0x61, 0x77, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x38, 0x0a, 0x0b,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x64, 0x44, 0x72, 0x61, 0x77, 0x12, 0x13, 0x2e, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x64, 0x44, 0x72, 0x61, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x14, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x64, 0x44, 0x72, 0x61, 0x77, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x44, 0x0a, 0x0f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x54, 0x61, 0x6b, 0x65, 0x62, 0x61, 0x63, 0x6b, 0x12, 0x17, 0x2e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x54, 0x61, 0x6b, 0x65, 0x62, 0x61, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x18, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x54, 0x61, 0x6b, 0x65,
+	0x62, 0x61, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x44, 0x0a, 0x0f,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x64, 0x54, 0x61, 0x6b, 0x65, 0x62, 0x61, 0x63, 0x6b, 0x12,
+	0x17, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x64, 0x54, 0x61, 0x6b, 0x65, 0x62, 0x61, 0x63,
+	0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x64, 0x54, 0x61, 0x6b, 0x65, 0x62, 0x61, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x42, 0x1e, 0x5a, 0x1c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x64, 0x75, 0x6d, 0x62, 0x6f, 0x67, 0x6f, 0x2f, 0x63, 0x68, 0x65, 0x73, 0x73, 0x2f, 0x61,
+	0x70, 0x69, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_api_service_proto_rawDescOnce sync.Once
+	file_api_service_proto_rawDescData = file_api_service_proto_rawDesc
+)
+
+func file_api_service_proto_rawDescGZIP() []byte {
+	file_api_service_proto_rawDescOnce.Do(func() {
+		file_api_service_proto_rawDescData = protoimpl.X.CompressGZIP(file_api_service_proto_rawDescData)
+	})
+	return file_api_service_proto_rawDescData
+}
+
+var file_api_service_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_api_service_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
+var file_api_service_proto_goTypes = []interface{}{
+	(Color)(0),                      // 0: Color
+	(Promotion)(0),                  // 1: Promotion
+	(Opponent)(0),                   // 2: Opponent
+	(*StartGameRequest)(nil),        // 3: StartGameRequest
+	(*TimeControl)(nil),             // 4: TimeControl
+	(*StartGameResponse)(nil),       // 5: StartGameResponse
+	(*JoinGameRequest)(nil),         // 6: JoinGameRequest
+	(*JoinGameResponse)(nil),        // 7: JoinGameResponse
+	(*MoveRequest)(nil),             // 8: MoveRequest
+	(*BoardStyle)(nil),              // 9: BoardStyle
+	(*MoveResponse)(nil),            // 10: MoveResponse
+	(*WatchRequest)(nil),            // 11: WatchRequest
+	(*WatchResponse)(nil),           // 12: WatchResponse
+	(*ExportPGNRequest)(nil),        // 13: ExportPGNRequest
+	(*ExportPGNResponse)(nil),       // 14: ExportPGNResponse
+	(*ResignRequest)(nil),           // 15: ResignRequest
+	(*ResignResponse)(nil),          // 16: ResignResponse
+	(*OfferDrawRequest)(nil),        // 17: OfferDrawRequest
+	(*OfferDrawResponse)(nil),       // 18: OfferDrawResponse
+	(*RespondDrawRequest)(nil),      // 19: RespondDrawRequest
+	(*RespondDrawResponse)(nil),     // 20: RespondDrawResponse
+	(*RequestTakebackRequest)(nil),  // 21: RequestTakebackRequest
+	(*RequestTakebackResponse)(nil), // 22: RequestTakebackResponse
+	(*RespondTakebackRequest)(nil),  // 23: RespondTakebackRequest
+	(*RespondTakebackResponse)(nil), // 24: RespondTakebackResponse
+}
+var file_api_service_proto_depIdxs = []int32{
+	0,  // 0: StartGameRequest.color:type_name -> Color
+	2,  // 1: StartGameRequest.opponent:type_name -> Opponent
+	4,  // 2: StartGameRequest.time_control:type_name -> TimeControl
+	0,  // 3: JoinGameResponse.color:type_name -> Color
+	0,  // 4: MoveRequest.color:type_name -> Color
+	1,  // 5: MoveRequest.promotion:type_name -> Promotion
+	9,  // 6: MoveRequest.board_style:type_name -> BoardStyle
+	9,  // 7: WatchRequest.board_style:type_name -> BoardStyle
+	0,  // 8: WatchResponse.draw_offer_color:type_name -> Color
+	0,  // 9: WatchResponse.takeback_request_color:type_name -> Color
+	3,  // 10: ChessService.StartGame:input_type -> StartGameRequest
+	6,  // 11: ChessService.JoinGame:input_type -> JoinGameRequest
+	8,  // 12: ChessService.Move:input_type -> MoveRequest
+	11, // 13: ChessService.Watch:input_type -> WatchRequest
+	13, // 14: ChessService.ExportPGN:input_type -> ExportPGNRequest
+	15, // 15: ChessService.Resign:input_type -> ResignRequest
+	17, // 16: ChessService.OfferDraw:input_type -> OfferDrawRequest
+	19, // 17: ChessService.RespondDraw:input_type -> RespondDrawRequest
+	21, // 18: ChessService.RequestTakeback:input_type -> RequestTakebackRequest
+	23, // 19: ChessService.RespondTakeback:input_type -> RespondTakebackRequest
+	5,  // 20: ChessService.StartGame:output_type -> StartGameResponse
+	7,  // 21: ChessService.JoinGame:output_type -> JoinGameResponse
+	10, // 22: ChessService.Move:output_type -> MoveResponse
+	12, // 23: ChessService.Watch:output_type -> WatchResponse
+	14, // 24: ChessService.ExportPGN:output_type -> ExportPGNResponse
+	16, // 25: ChessService.Resign:output_type -> ResignResponse
+	18, // 26: ChessService.OfferDraw:output_type -> OfferDrawResponse
+	20, // 27: ChessService.RespondDraw:output_type -> RespondDrawResponse
+	22, // 28: ChessService.RequestTakeback:output_type -> RequestTakebackResponse
+	24, // 29: ChessService.RespondTakeback:output_type -> RespondTakebackResponse
+	20, // [20:30] is the sub-list for method output_type
+	10, // [10:20] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_api_service_proto_init() }
+func file_api_service_proto_init() {
+	if File_api_service_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_api_service_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StartGameRequest); i {
+			case 0:
+				return &v.state
 			case 1:
 				return &v.sizeCache
 			case 2:
@@ -645,7 +1775,7 @@ func file_api_service_proto_init() {
 			}
 		}
 		file_api_service_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StartGameResponse); i {
+			switch v := v.(*TimeControl); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -657,7 +1787,7 @@ func file_api_service_proto_init() {
 			}
 		}
 		file_api_service_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*JoinGameRequest); i {
+			switch v := v.(*StartGameResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -669,7 +1799,7 @@ func file_api_service_proto_init() {
 			}
 		}
 		file_api_service_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*JoinGameResponse); i {
+			switch v := v.(*JoinGameRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -681,7 +1811,7 @@ func file_api_service_proto_init() {
 			}
 		}
 		file_api_service_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MoveRequest); i {
+			switch v := v.(*JoinGameResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -693,7 +1823,7 @@ func file_api_service_proto_init() {
 			}
 		}
 		file_api_service_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MoveResponse); i {
+			switch v := v.(*MoveRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -705,7 +1835,7 @@ func file_api_service_proto_init() {
 			}
 		}
 		file_api_service_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*WatchRequest); i {
+			switch v := v.(*BoardStyle); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -717,6 +1847,30 @@ func file_api_service_proto_init() {
 			}
 		}
 		file_api_service_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MoveResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_service_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_service_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*WatchResponse); i {
 			case 0:
 				return &v.state
@@ -728,14 +1882,158 @@ func file_api_service_proto_init() {
 				return nil
 			}
 		}
+		file_api_service_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportPGNRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_service_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportPGNResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_service_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResignRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_service_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResignResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_service_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OfferDrawRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_service_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OfferDrawResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_service_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RespondDrawRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_service_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RespondDrawResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_service_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RequestTakebackRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_service_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RequestTakebackResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_service_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RespondTakebackRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_service_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RespondTakebackResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_api_service_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   8,
+			NumEnums:      3,
+			NumMessages:   22,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
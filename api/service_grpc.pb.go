@@ -22,6 +22,12 @@ type ChessServiceClient interface {
 	JoinGame(ctx context.Context, in *JoinGameRequest, opts ...grpc.CallOption) (*JoinGameResponse, error)
 	Move(ctx context.Context, in *MoveRequest, opts ...grpc.CallOption) (*MoveResponse, error)
 	Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (ChessService_WatchClient, error)
+	ExportPGN(ctx context.Context, in *ExportPGNRequest, opts ...grpc.CallOption) (*ExportPGNResponse, error)
+	Resign(ctx context.Context, in *ResignRequest, opts ...grpc.CallOption) (*ResignResponse, error)
+	OfferDraw(ctx context.Context, in *OfferDrawRequest, opts ...grpc.CallOption) (*OfferDrawResponse, error)
+	RespondDraw(ctx context.Context, in *RespondDrawRequest, opts ...grpc.CallOption) (*RespondDrawResponse, error)
+	RequestTakeback(ctx context.Context, in *RequestTakebackRequest, opts ...grpc.CallOption) (*RequestTakebackResponse, error)
+	RespondTakeback(ctx context.Context, in *RespondTakebackRequest, opts ...grpc.CallOption) (*RespondTakebackResponse, error)
 }
 
 type chessServiceClient struct {
@@ -91,6 +97,60 @@ func (x *chessServiceWatchClient) Recv() (*WatchResponse, error) {
 	return m, nil
 }
 
+func (c *chessServiceClient) ExportPGN(ctx context.Context, in *ExportPGNRequest, opts ...grpc.CallOption) (*ExportPGNResponse, error) {
+	out := new(ExportPGNResponse)
+	err := c.cc.Invoke(ctx, "/ChessService/ExportPGN", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chessServiceClient) Resign(ctx context.Context, in *ResignRequest, opts ...grpc.CallOption) (*ResignResponse, error) {
+	out := new(ResignResponse)
+	err := c.cc.Invoke(ctx, "/ChessService/Resign", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chessServiceClient) OfferDraw(ctx context.Context, in *OfferDrawRequest, opts ...grpc.CallOption) (*OfferDrawResponse, error) {
+	out := new(OfferDrawResponse)
+	err := c.cc.Invoke(ctx, "/ChessService/OfferDraw", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chessServiceClient) RespondDraw(ctx context.Context, in *RespondDrawRequest, opts ...grpc.CallOption) (*RespondDrawResponse, error) {
+	out := new(RespondDrawResponse)
+	err := c.cc.Invoke(ctx, "/ChessService/RespondDraw", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chessServiceClient) RequestTakeback(ctx context.Context, in *RequestTakebackRequest, opts ...grpc.CallOption) (*RequestTakebackResponse, error) {
+	out := new(RequestTakebackResponse)
+	err := c.cc.Invoke(ctx, "/ChessService/RequestTakeback", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chessServiceClient) RespondTakeback(ctx context.Context, in *RespondTakebackRequest, opts ...grpc.CallOption) (*RespondTakebackResponse, error) {
+	out := new(RespondTakebackResponse)
+	err := c.cc.Invoke(ctx, "/ChessService/RespondTakeback", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ChessServiceServer is the server API for ChessService service.
 // All implementations must embed UnimplementedChessServiceServer
 // for forward compatibility
@@ -99,6 +159,12 @@ type ChessServiceServer interface {
 	JoinGame(context.Context, *JoinGameRequest) (*JoinGameResponse, error)
 	Move(context.Context, *MoveRequest) (*MoveResponse, error)
 	Watch(*WatchRequest, ChessService_WatchServer) error
+	ExportPGN(context.Context, *ExportPGNRequest) (*ExportPGNResponse, error)
+	Resign(context.Context, *ResignRequest) (*ResignResponse, error)
+	OfferDraw(context.Context, *OfferDrawRequest) (*OfferDrawResponse, error)
+	RespondDraw(context.Context, *RespondDrawRequest) (*RespondDrawResponse, error)
+	RequestTakeback(context.Context, *RequestTakebackRequest) (*RequestTakebackResponse, error)
+	RespondTakeback(context.Context, *RespondTakebackRequest) (*RespondTakebackResponse, error)
 	mustEmbedUnimplementedChessServiceServer()
 }
 
@@ -118,6 +184,24 @@ func (UnimplementedChessServiceServer) Move(context.Context, *MoveRequest) (*Mov
 func (UnimplementedChessServiceServer) Watch(*WatchRequest, ChessService_WatchServer) error {
 	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
 }
+func (UnimplementedChessServiceServer) ExportPGN(context.Context, *ExportPGNRequest) (*ExportPGNResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportPGN not implemented")
+}
+func (UnimplementedChessServiceServer) Resign(context.Context, *ResignRequest) (*ResignResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Resign not implemented")
+}
+func (UnimplementedChessServiceServer) OfferDraw(context.Context, *OfferDrawRequest) (*OfferDrawResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OfferDraw not implemented")
+}
+func (UnimplementedChessServiceServer) RespondDraw(context.Context, *RespondDrawRequest) (*RespondDrawResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RespondDraw not implemented")
+}
+func (UnimplementedChessServiceServer) RequestTakeback(context.Context, *RequestTakebackRequest) (*RequestTakebackResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestTakeback not implemented")
+}
+func (UnimplementedChessServiceServer) RespondTakeback(context.Context, *RespondTakebackRequest) (*RespondTakebackResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RespondTakeback not implemented")
+}
 func (UnimplementedChessServiceServer) mustEmbedUnimplementedChessServiceServer() {}
 
 // UnsafeChessServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -206,6 +290,114 @@ func (x *chessServiceWatchServer) Send(m *WatchResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _ChessService_ExportPGN_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportPGNRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChessServiceServer).ExportPGN(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ChessService/ExportPGN",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChessServiceServer).ExportPGN(ctx, req.(*ExportPGNRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChessService_Resign_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResignRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChessServiceServer).Resign(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ChessService/Resign",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChessServiceServer).Resign(ctx, req.(*ResignRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChessService_OfferDraw_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OfferDrawRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChessServiceServer).OfferDraw(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ChessService/OfferDraw",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChessServiceServer).OfferDraw(ctx, req.(*OfferDrawRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChessService_RespondDraw_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RespondDrawRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChessServiceServer).RespondDraw(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ChessService/RespondDraw",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChessServiceServer).RespondDraw(ctx, req.(*RespondDrawRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChessService_RequestTakeback_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestTakebackRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChessServiceServer).RequestTakeback(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ChessService/RequestTakeback",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChessServiceServer).RequestTakeback(ctx, req.(*RequestTakebackRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChessService_RespondTakeback_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RespondTakebackRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChessServiceServer).RespondTakeback(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ChessService/RespondTakeback",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChessServiceServer).RespondTakeback(ctx, req.(*RespondTakebackRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ChessService_ServiceDesc is the grpc.ServiceDesc for ChessService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -225,6 +417,30 @@ var ChessService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Move",
 			Handler:    _ChessService_Move_Handler,
 		},
+		{
+			MethodName: "ExportPGN",
+			Handler:    _ChessService_ExportPGN_Handler,
+		},
+		{
+			MethodName: "Resign",
+			Handler:    _ChessService_Resign_Handler,
+		},
+		{
+			MethodName: "OfferDraw",
+			Handler:    _ChessService_OfferDraw_Handler,
+		},
+		{
+			MethodName: "RespondDraw",
+			Handler:    _ChessService_RespondDraw_Handler,
+		},
+		{
+			MethodName: "RequestTakeback",
+			Handler:    _ChessService_RequestTakeback_Handler,
+		},
+		{
+			MethodName: "RespondTakeback",
+			Handler:    _ChessService_RespondTakeback_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -0,0 +1,94 @@
+package api
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/dumbogo/chess/engine"
+)
+
+// Sweep finishes every interval the games whose player in turn ran out of time, or did not move on
+// untimed games within abandonAfter, never when zero, and plays the computer turns left pending,
+// finishing by abandonment those failing maxComputerFailures times, until ctx is done. It is safe to run on every replica, a game is finished or moved only once
+func Sweep(ctx context.Context, interval, abandonAfter time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := sweep(time.Now(), abandonAfter); err != nil {
+			log.Printf("failed to sweep games: %v", err)
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// sweep finishes the games timed out or abandoned at now, and plays the computer turns pending,
+// returns the number of games finished
+func sweep(now time.Time, abandonAfter time.Duration) (int, error) {
+	// clocks are stored in nanoseconds, intervals have microseconds precision
+	query := DBConn.Where("turn_started_at + (CASE WHEN turn = black_player_id THEN black_time ELSE white_time END / 1000) * interval '1 microsecond' <= ?", now)
+	if abandonAfter > 0 {
+		query = query.Or("base_time = 0 AND days_per_move = 0 AND updated_at <= ?", now.Add(-abandonAfter))
+	}
+	query = query.Or("updated_at <= ? AND turn IN (SELECT id FROM players WHERE level > 0)", now.Add(-computerRetryAfter))
+	games := []Game{}
+	tx := DBConn.Where("winner = 0 AND white_player_id IS NOT NULL AND black_player_id IS NOT NULL").Where(query).Find(&games)
+	if tx.Error != nil {
+		return 0, tx.Error
+	}
+	finished := 0
+	for _, g := range games {
+		ok, err := sweepGame(g.UUID.String(), now, abandonAfter)
+		if err != nil {
+			log.Printf("failed to sweep game %s: %v", g.UUID, err)
+			continue
+		}
+		if ok {
+			finished++
+		}
+	}
+	return finished, nil
+}
+
+// sweepGame finishes game uuid if timed out or abandoned at now, otherwise plays the computer turn
+// pending since computerRetryAfter. Returns true if this call finished it
+func sweepGame(uuid string, now time.Time, abandonAfter time.Duration) (bool, error) {
+	gameDb, whitePlayerDb, blackPlayerDb, err := loadGameAndPlayers(uuid)
+	if err != nil {
+		return false, err
+	}
+	if gameDb.Winner != 0 {
+		return false, nil
+	}
+	turn, turnPlayer := whitePlayerDb, engine.Player{Color: engine.WhiteColor}
+	if gameDb.Turn == blackPlayerDb.ID {
+		turn, turnPlayer.Color = blackPlayerDb, engine.BlackColor
+	}
+	timedOut := gameDb.flagFallen(now)
+	// the computer abandons only once its movement kept failing, otherwise it is pending,
+	// i.e. the server restarted while searching
+	computerFailed := turn.Level > 0 && gameDb.ComputerFailures >= maxComputerFailures
+	if !timedOut && turn.Level > 0 && !computerFailed {
+		if gameDb.UpdatedAt.After(now.Add(-computerRetryAfter)) {
+			return false, nil
+		}
+		return false, computerMove(uuid)
+	}
+	abandoned := computerFailed || abandonAfter > 0 && !gameDb.timed() && !gameDb.UpdatedAt.After(now.Add(-abandonAfter))
+	if !timedOut && !abandoned {
+		return false, nil
+	}
+	gameEngine, err := loadEngineGameFromDbValues(gameDb, turnPlayer)
+	if err != nil {
+		return false, err
+	}
+	reason := engine.TimeoutReason
+	if !timedOut {
+		reason = engine.AbandonmentReason
+	}
+	return finishGame(&gameDb, gameEngine, oponentWins(turnPlayer.Color), reason, now)
+}
@@ -0,0 +1,118 @@
+// +build integration
+
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dumbogo/chess/engine"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSweep(t *testing.T) {
+	assert := assert.New(t)
+	server := factoryServer()
+	ctx, cancel := createCtxMetadataUser(&User{AccessToken: "hereistoken123", Email: "some@mail.com"})
+	defer cancel()
+	ctxJoin, cancelJoin := createCtxMetadataUser(&User{AccessToken: "someothertoken", Email: "other@mail.com"})
+	defer cancelJoin()
+
+	timed, err := server.StartGame(ctx, &StartGameRequest{Name: "timed", Color: Color_WHITE, TimeControl: &TimeControl{BaseSeconds: 60}})
+	assert.Nil(err)
+	_, err = server.JoinGame(ctxJoin, &JoinGameRequest{Uuid: timed.GetUuid()})
+	assert.Nil(err)
+	_, err = server.Move(ctx, &MoveRequest{Uuid: timed.GetUuid(), San: "e4"})
+	assert.Nil(err)
+
+	untimed, err := server.StartGame(ctx, &StartGameRequest{Name: "untimed", Color: Color_WHITE})
+	assert.Nil(err)
+	_, err = server.JoinGame(ctxJoin, &JoinGameRequest{Uuid: untimed.GetUuid()})
+	assert.Nil(err)
+
+	// waiting for an oponent, never abandoned
+	_, err = server.StartGame(ctx, &StartGameRequest{Name: "waiting", Color: Color_WHITE})
+	assert.Nil(err)
+
+	now := time.Now()
+	finished, err := sweep(now, time.Hour)
+	assert.Nil(err)
+	assert.Equal(0, finished)
+
+	// black flag falls, and white abandons the untimed game
+	finished, err = sweep(now.Add(2*time.Hour), time.Hour)
+	assert.Nil(err)
+	assert.Equal(2, finished)
+	// Case: games are finished once
+	finished, err = sweep(now.Add(2*time.Hour), time.Hour)
+	assert.Nil(err)
+	assert.Equal(0, finished)
+
+	var gameDb Game
+	assert.Nil(DBConn.Where("uuid = ?", timed.GetUuid()).First(&gameDb).Error)
+	assert.Equal("game finished, white wins by timeout", gameDb.status())
+	assert.Equal(time.Duration(0), gameDb.BlackTime)
+	assert.Nil(DBConn.Where("uuid = ?", untimed.GetUuid()).First(&gameDb).Error)
+	assert.Equal(int(engine.BlackWinsResult), gameDb.Winner)
+	assert.Equal("game finished, black wins by abandonment", gameDb.status())
+
+	_, err = server.Move(ctxJoin, &MoveRequest{Uuid: timed.GetUuid(), San: "e5"})
+	assert.EqualError(err, "game finished, white wins by timeout")
+}
+
+func TestSweepComputer(t *testing.T) {
+	assert := assert.New(t)
+	server := factoryServer()
+	ctx, cancel := createCtxMetadataUser(&User{AccessToken: "sweepcomputertoken", Email: "sweepcomputer@mail.com"})
+	defer cancel()
+
+	r, err := server.StartGame(ctx, &StartGameRequest{Name: "pending", Color: Color_WHITE, Opponent: Opponent_COMPUTER, Level: 1})
+	assert.Nil(err)
+	// the user moves but the computer reply is lost, i.e. the server restarted while searching
+	gameDb, whitePlayerDb, blackPlayerDb, err := loadGameAndPlayers(r.GetUuid())
+	assert.Nil(err)
+	gameEngine, err := loadEngineGameFromDbValues(gameDb, engine.Player{Color: engine.WhiteColor})
+	assert.Nil(err)
+	m, err := gameEngine.ParseSAN("e4")
+	assert.Nil(err)
+	ok, err := gameEngine.Move(engine.Player{Color: engine.WhiteColor}, m.From, m.To, m.Promotion)
+	assert.True(ok)
+	assert.Nil(err)
+	assert.Nil(saveMovement(&gameDb, gameEngine, whitePlayerDb.ID, blackPlayerDb.ID))
+
+	// Case: the computer may be still searching
+	now := time.Now()
+	_, err = sweep(now, 0)
+	assert.Nil(err)
+	var movements int64
+	assert.Nil(DBConn.Model(&Movement{}).Where("game_id=?", gameDb.ID).Count(&movements).Error)
+	assert.Equal(int64(1), movements)
+
+	finished, err := sweep(now.Add(2*computerRetryAfter), 0)
+	assert.Nil(err)
+	assert.Equal(0, finished)
+	assert.Nil(DBConn.Model(&Movement{}).Where("game_id=?", gameDb.ID).Count(&movements).Error)
+	assert.Equal(int64(2), movements)
+	gameDb, _, _, err = loadGameAndPlayers(r.GetUuid())
+	assert.Nil(err)
+	assert.Equal(whitePlayerDb.ID, gameDb.Turn)
+
+	// Case: the computer loses by abandonment once its movement failed maxComputerFailures times
+	gameEngine, err = loadEngineGameFromDbValues(gameDb, engine.Player{Color: engine.WhiteColor})
+	assert.Nil(err)
+	m, err = gameEngine.ParseSAN("d4")
+	assert.Nil(err)
+	ok, err = gameEngine.Move(engine.Player{Color: engine.WhiteColor}, m.From, m.To, m.Promotion)
+	assert.True(ok)
+	assert.Nil(err)
+	assert.Nil(saveMovement(&gameDb, gameEngine, whitePlayerDb.ID, blackPlayerDb.ID))
+	assert.Nil(DBConn.Model(&Game{}).Where("id = ?", gameDb.ID).UpdateColumn("computer_failures", maxComputerFailures).Error)
+	finished, err = sweep(time.Now().Add(2*computerRetryAfter), 0)
+	assert.Nil(err)
+	assert.Equal(1, finished)
+	gameDb, _, _, err = loadGameAndPlayers(r.GetUuid())
+	assert.Nil(err)
+	assert.Equal("game finished, white wins by abandonment", gameDb.status())
+	assert.Nil(DBConn.Model(&Movement{}).Where("game_id=?", gameDb.ID).Count(&movements).Error)
+	assert.Equal(int64(3), movements)
+}
@@ -12,6 +12,7 @@ import (
 
 	pb "github.com/dumbogo/chess/api"
 	"github.com/dumbogo/chess/config"
+	"github.com/dumbogo/chess/engine"
 	"golang.org/x/oauth2"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
@@ -22,6 +23,35 @@ const defaultTimeOutContext = time.Second
 
 var clientConfig *config.ClientConfiguration
 
+// boardOptions options boards are drawn with
+var boardOptions = engine.RenderOptions{Unicode: true, Coordinates: true}
+
+// SetBoardOptions sets the options boards are drawn with, boards of the configured game
+// are flipped again when playing black
+func SetBoardOptions(options engine.RenderOptions) {
+	boardOptions = options
+}
+
+// gameBoardOptions returns the options the board of game uuid is drawn with,
+// from black side when it is the configured game played with black
+func gameBoardOptions(uuid string) engine.RenderOptions {
+	options := boardOptions
+	if clientConfig != nil && uuid == clientConfig.Game.UUID && clientConfig.Game.Color == pb.Color_BLACK.String() {
+		options.Flip = !options.Flip
+	}
+	return options
+}
+
+// boardStyle returns the style the server draws boards with, as options
+func boardStyle(options engine.RenderOptions) *pb.BoardStyle {
+	return &pb.BoardStyle{
+		Unicode:     options.Unicode,
+		Color:       options.Color,
+		Coordinates: options.Coordinates,
+		Flip:        options.Flip,
+	}
+}
+
 // InitConn loads configuration and initializes connection to server
 func InitConn() (*grpc.ClientConn, error) {
 	var err error
@@ -56,37 +86,53 @@ func InitConn() (*grpc.ClientConn, error) {
 	return grpc.Dial(clientConfig.APIServerURL, opts...)
 }
 
-// StartGame creates a new Game
-func StartGame(conn *grpc.ClientConn, name string, color pb.Color) {
+// StartGame creates a new Game, against the computer with strength level when opponent is COMPUTER
+func StartGame(conn *grpc.ClientConn, name string, color pb.Color, opponent pb.Opponent, level uint32, timeControl *pb.TimeControl) {
 	c := pb.NewChessServiceClient(conn)
 	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeOutContext)
 	defer cancel()
-	r, err := c.StartGame(ctx, &pb.StartGameRequest{Name: name, Color: color})
+	r, err := c.StartGame(ctx, &pb.StartGameRequest{Name: name, Color: color, Opponent: opponent, Level: level, TimeControl: timeControl})
 	if err != nil {
 		log.Fatalf("could not start game: %v", err)
 	}
-	fmt.Printf("UUID to connect: %s\n Please share this UUID to your fellow in order to play\n", r.GetUuid())
+	if opponent == pb.Opponent_COMPUTER {
+		fmt.Printf("UUID: %s\n Playing against the computer, use watch to see its movements\n", r.GetUuid())
+	} else {
+		fmt.Printf("UUID to connect: %s\n Please share this UUID to your fellow in order to play\n", r.GetUuid())
+	}
 	if err := clientConfig.UpdateGame(r.GetUuid(), name, color.String()); err != nil {
 		panic(err)
 	}
 }
 
-// Move call move piece server and print movement
-func Move(conn *grpc.ClientConn, fromSquare, toSquare string) {
+// Move call move piece server and print movement, promotion is required when a pawn reaches the last rank
+func Move(conn *grpc.ClientConn, fromSquare, toSquare string, promotion pb.Promotion) {
+	move(conn, &pb.MoveRequest{
+		FromSquare: fromSquare,
+		ToSquare:   toSquare,
+		Promotion:  promotion,
+	})
+}
+
+// MoveSAN call move piece server with a movement in Standard Algebraic Notation, i.e. "Nf3", and print movement
+func MoveSAN(conn *grpc.ClientConn, san string) {
+	move(conn, &pb.MoveRequest{San: san})
+}
+
+func move(conn *grpc.ClientConn, r *pb.MoveRequest) {
 	c := pb.NewChessServiceClient(conn)
 	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeOutContext)
 	defer cancel()
 	color := pb.Color_value[(clientConfig.Game.Color)]
-	r, err := c.Move(ctx, &pb.MoveRequest{
-		Color:      pb.Color(color),
-		Uuid:       clientConfig.Game.UUID,
-		FromSquare: fromSquare,
-		ToSquare:   toSquare,
-	})
+	r.Color = pb.Color(color)
+	r.Uuid = clientConfig.Game.UUID
+	r.BoardStyle = boardStyle(gameBoardOptions(r.Uuid))
+	moveResponse, err := c.Move(ctx, r)
 	if err != nil {
 		log.Fatalf("could not move piece: %v", err)
 	}
-	fmt.Printf("Board: \n:%s\n", r.GetBoard())
+	fmt.Printf("Moved: %s\n", moveResponse.GetSan())
+	fmt.Printf("Board: \n:%s\n", moveResponse.GetBoard())
 }
 
 // JoinGame calls join game server
@@ -115,7 +161,7 @@ func Watch(conn *grpc.ClientConn, uuid string) {
 	if uuid == "" {
 		panic(errors.New("No current game, please either provide a game uuid or create/join one"))
 	}
-	stream, err := c.Watch(context.Background(), &pb.WatchRequest{Uuid: uuid})
+	stream, err := c.Watch(context.Background(), &pb.WatchRequest{Uuid: uuid, BoardStyle: boardStyle(gameBoardOptions(uuid))})
 	if err != nil {
 		log.Fatalf("could not watch game: %v", err)
 	}
@@ -129,13 +175,144 @@ func Watch(conn *grpc.ClientConn, uuid string) {
 		if err != nil {
 			log.Fatalf("%v.Watch(_) = _, %v", c, err)
 		}
+		if lastMove := watchResponse.GetLastMove(); lastMove != "" {
+			fmt.Printf("Last move: %s\n", lastMove)
+		}
 		fmt.Printf("Turn player: %s\n", watchResponse.GetTurn())
 
 		fmt.Printf("Status: %s\n", watchResponse.GetStatus())
+		if watchResponse.GetTimed() {
+			fmt.Println(clocks(watchResponse))
+		}
+		if offer := drawOffer(watchResponse); offer != "" {
+			fmt.Println(offer)
+		}
+		if request := takebackRequest(watchResponse); request != "" {
+			fmt.Println(request)
+		}
+		if fen := watchResponse.GetFen(); fen != "" {
+			game, _ := replay(fen, watchResponse.GetMoves())
+			fmt.Println(game.Render(gameBoardOptions(uuid)))
+			continue
+		}
 		fmt.Println(watchResponse.GetBoard())
 	}
 }
 
+// ExportPGN prints a game in Portable Game Notation, by uuid, if not provided, uses the configured by client
+func ExportPGN(conn *grpc.ClientConn, uuid string) {
+	c := pb.NewChessServiceClient(conn)
+	if uuid == "" {
+		uuid = clientConfig.Game.UUID
+	}
+	if uuid == "" {
+		log.Fatalf("No current game, please either provide a game uuid or create/join one")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeOutContext)
+	defer cancel()
+	r, err := c.ExportPGN(ctx, &pb.ExportPGNRequest{Uuid: uuid})
+	if err != nil {
+		log.Fatalf("could not export game: %v", err)
+	}
+	fmt.Print(r.GetPgn())
+}
+
+// Resign resigns the configured game, the oponent wins
+func Resign(conn *grpc.ClientConn) {
+	c := pb.NewChessServiceClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeOutContext)
+	defer cancel()
+	r, err := c.Resign(ctx, &pb.ResignRequest{Uuid: clientConfig.Game.UUID})
+	if err != nil {
+		log.Fatalf("could not resign: %v", err)
+	}
+	fmt.Printf("Status: %s\n", r.GetStatus())
+}
+
+// OfferDraw offers a draw to the oponent of the configured game
+func OfferDraw(conn *grpc.ClientConn) {
+	c := pb.NewChessServiceClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeOutContext)
+	defer cancel()
+	if _, err := c.OfferDraw(ctx, &pb.OfferDrawRequest{Uuid: clientConfig.Game.UUID}); err != nil {
+		log.Fatalf("could not offer draw: %v", err)
+	}
+	fmt.Println("Draw offered, waiting for your oponent to respond")
+}
+
+// RespondDraw accepts or declines the draw offered by the oponent of the configured game
+func RespondDraw(conn *grpc.ClientConn, accept bool) {
+	c := pb.NewChessServiceClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeOutContext)
+	defer cancel()
+	r, err := c.RespondDraw(ctx, &pb.RespondDrawRequest{Uuid: clientConfig.Game.UUID, Accept: accept})
+	if err != nil {
+		log.Fatalf("could not respond draw: %v", err)
+	}
+	fmt.Printf("Status: %s\n", r.GetStatus())
+}
+
+// RequestTakeback asks the oponent of the configured game to take back your last movement
+func RequestTakeback(conn *grpc.ClientConn) {
+	c := pb.NewChessServiceClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeOutContext)
+	defer cancel()
+	r, err := c.RequestTakeback(ctx, &pb.RequestTakebackRequest{Uuid: clientConfig.Game.UUID})
+	if err != nil {
+		log.Fatalf("could not request takeback: %v", err)
+	}
+	fmt.Printf("Takeback requested\nStatus: %s\n", r.GetStatus())
+}
+
+// RespondTakeback accepts or declines the takeback requested by the oponent of the configured game
+func RespondTakeback(conn *grpc.ClientConn, accept bool) {
+	c := pb.NewChessServiceClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeOutContext)
+	defer cancel()
+	r, err := c.RespondTakeback(ctx, &pb.RespondTakebackRequest{Uuid: clientConfig.Game.UUID, Accept: accept})
+	if err != nil {
+		log.Fatalf("could not respond takeback: %v", err)
+	}
+	fmt.Printf("Status: %s\n", r.GetStatus())
+}
+
+// takebackRequest returns the takeback requested, empty when none
+func takebackRequest(r *pb.WatchResponse) string {
+	if !r.GetTakebackRequest() {
+		return ""
+	}
+	return fmt.Sprintf("Takeback requested by %s", strings.ToLower(r.GetTakebackRequestColor().String()))
+}
+
+// drawOffer returns the draw offered, empty when none
+func drawOffer(r *pb.WatchResponse) string {
+	if !r.GetDrawOffer() {
+		return ""
+	}
+	return fmt.Sprintf("Draw offered by %s", strings.ToLower(r.GetDrawOfferColor().String()))
+}
+
+// clocks returns the time left of both players
+func clocks(r *pb.WatchResponse) string {
+	return fmt.Sprintf("Clock: white %s, black %s", formatClock(r.GetWhiteTimeMs()), formatClock(r.GetBlackTimeMs()))
+}
+
+// formatClock formats a time left in milliseconds as m:ss, h:mm:ss or days and hours on correspondence games
+func formatClock(ms int64) string {
+	d := time.Duration(ms) * time.Millisecond
+	if d < 0 {
+		d = 0
+	}
+	hours, minutes, seconds := int(d/time.Hour), int(d/time.Minute)%60, int(d/time.Second)%60
+	switch {
+	case hours >= 24:
+		return fmt.Sprintf("%dd %dh", hours/24, hours%24)
+	case hours > 0:
+		return fmt.Sprintf("%d:%02d:%02d", hours, minutes, seconds)
+	}
+	return fmt.Sprintf("%d:%02d", minutes, seconds)
+}
+
 func relPathtoFilePath(path string) (string, error) {
 	if !strings.Contains(path, "$HOME") {
 		return path, nil
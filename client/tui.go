@@ -0,0 +1,329 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	pb "github.com/dumbogo/chess/api"
+	"github.com/dumbogo/chess/engine"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// ANSI escape sequences used by the TUI
+const (
+	ansiAltScreen  = "\x1b[?1049h"
+	ansiMainScreen = "\x1b[?1049l"
+	ansiClear      = "\x1b[H\x1b[2J"
+	ansiReset      = "\x1b[0m"
+	ansiBold       = "\x1b[1m"
+)
+
+// Watch stream reconnection delays, doubled on each failed attempt
+const (
+	tuiMinBackoff = time.Second
+	tuiMaxBackoff = 16 * time.Second
+)
+
+// tuiMoveLines movement list lines drawn beside the board
+const tuiMoveLines = 10
+
+var longAlgebraicRegexp = regexp.MustCompile(`^([a-h][1-8])([a-h][1-8])([qrbn]?)$`)
+
+var longAlgebraicPromotions = map[string]pb.Promotion{
+	"":  pb.Promotion_NO_PROMOTION,
+	"q": pb.Promotion_QUEEN,
+	"r": pb.Promotion_ROOK,
+	"b": pb.Promotion_BISHOP,
+	"n": pb.Promotion_KNIGHT,
+}
+
+// watchEvent a Watch stream update, or the error which closed the stream
+type watchEvent struct {
+	response *pb.WatchResponse
+	err      error
+}
+
+// tui full-screen terminal client of a game
+type tui struct {
+	client pb.ChessServiceClient
+	uuid   string
+	out    io.Writer
+
+	// board options the board is drawn with, flipped by the flip command
+	board engine.RenderOptions
+	// game position replayed from the movements received
+	game      engine.Game
+	status    string
+	clocks    string
+	drawOffer string
+	takeback  string
+	connected bool
+	message   string
+	moves     []string
+}
+
+// TUI runs a full-screen terminal client of game uuid, if not provided, uses the configured by client.
+// The board is drawn colored from the configured color perspective, moves are typed on the prompt
+func TUI(conn *grpc.ClientConn, uuid string, in io.Reader, out io.Writer) error {
+	if uuid == "" {
+		uuid = clientConfig.Game.UUID
+	}
+	if uuid == "" {
+		return errors.New("No current game, please either provide a game uuid or create/join one")
+	}
+	game, _ := engine.ParseFEN(engine.StartingFEN)
+	board := gameBoardOptions(uuid)
+	board.Color, board.Coordinates = true, true
+	t := &tui{
+		client: pb.NewChessServiceClient(conn),
+		uuid:   uuid,
+		out:    out,
+		board:  board,
+		game:   game,
+		status: "connecting...",
+	}
+	return t.run(in)
+}
+
+func (t *tui) run(in io.Reader) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events := make(chan watchEvent)
+	go t.watch(ctx, events)
+	lines := make(chan string)
+	go func() {
+		scanner := bufio.NewScanner(in)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+		close(lines)
+	}()
+
+	fmt.Fprint(t.out, ansiAltScreen)
+	defer fmt.Fprint(t.out, ansiMainScreen)
+	for {
+		t.draw()
+		select {
+		case event := <-events:
+			t.update(event)
+		case line, ok := <-lines:
+			if !ok {
+				return nil
+			}
+			switch command := strings.TrimSpace(line); command {
+			case "":
+			case "quit", "exit":
+				return nil
+			case "flip":
+				t.board.Flip = !t.board.Flip
+			case "resign":
+				t.call(func(ctx context.Context) error {
+					_, err := t.client.Resign(ctx, &pb.ResignRequest{Uuid: t.uuid})
+					return err
+				})
+			case "draw":
+				t.call(func(ctx context.Context) error {
+					_, err := t.client.OfferDraw(ctx, &pb.OfferDrawRequest{Uuid: t.uuid})
+					return err
+				})
+			case "takeback":
+				t.call(func(ctx context.Context) error {
+					_, err := t.client.RequestTakeback(ctx, &pb.RequestTakebackRequest{Uuid: t.uuid})
+					return err
+				})
+			case "accept", "decline":
+				// responds the takeback requested, otherwise the draw offered
+				accept := command == "accept"
+				t.call(func(ctx context.Context) error {
+					if t.takeback != "" {
+						_, err := t.client.RespondTakeback(ctx, &pb.RespondTakebackRequest{Uuid: t.uuid, Accept: accept})
+						return err
+					}
+					_, err := t.client.RespondDraw(ctx, &pb.RespondDrawRequest{Uuid: t.uuid, Accept: accept})
+					return err
+				})
+			default:
+				t.move(command)
+			}
+		}
+	}
+}
+
+// watch keeps the Watch stream open, reconnecting when it closes, until ctx is done
+func (t *tui) watch(ctx context.Context, events chan<- watchEvent) {
+	send := func(event watchEvent) bool {
+		select {
+		case events <- event:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+	backoff := tuiMinBackoff
+	for {
+		stream, err := t.client.Watch(ctx, &pb.WatchRequest{Uuid: t.uuid})
+		for err == nil {
+			var r *pb.WatchResponse
+			if r, err = stream.Recv(); err == nil {
+				backoff = tuiMinBackoff
+				if !send(watchEvent{response: r}) {
+					return
+				}
+			}
+		}
+		if !send(watchEvent{err: err}) {
+			return
+		}
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return
+		}
+		if backoff < tuiMaxBackoff {
+			backoff *= 2
+		}
+	}
+}
+
+// update applies a Watch stream event
+func (t *tui) update(event watchEvent) {
+	if event.err != nil {
+		t.connected = false
+		t.message = "connection lost, reconnecting: " + status.Convert(event.err).Message()
+		return
+	}
+	r := event.response
+	if !t.connected {
+		t.message = ""
+	}
+	t.connected = true
+	t.status = r.GetStatus()
+	t.clocks = ""
+	if r.GetTimed() {
+		t.clocks = clocks(r)
+	}
+	t.drawOffer = drawOffer(r)
+	t.takeback = takebackRequest(r)
+	if r.GetFen() != "" {
+		t.game, t.moves = replay(r.GetFen(), r.GetMoves())
+	}
+}
+
+// replay returns the game reaching fen playing moves from the starting position,
+// or the position of fen without movements when moves do not reach it
+func replay(fen string, moves []string) (engine.Game, []string) {
+	game, _ := engine.ParseFEN(engine.StartingFEN)
+	for _, san := range moves {
+		m, err := game.ParseSAN(san)
+		if err != nil {
+			break
+		}
+		game.Move(game.Turn(), m.From, m.To, m.Promotion)
+	}
+	if game.FEN() != fen {
+		if position, err := engine.ParseFEN(fen); err == nil {
+			return position, moves
+		}
+	}
+	return game, moves
+}
+
+// move sends a move typed in SAN, i.e. "Nf3", or long algebraic notation, i.e. "g1f3"
+func (t *tui) move(input string) {
+	r := &pb.MoveRequest{Uuid: t.uuid, San: input}
+	if match := longAlgebraicRegexp.FindStringSubmatch(input); match != nil {
+		r = &pb.MoveRequest{Uuid: t.uuid, FromSquare: match[1], ToSquare: match[2], Promotion: longAlgebraicPromotions[match[3]]}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeOutContext)
+	defer cancel()
+	if _, err := t.client.Move(ctx, r); err != nil {
+		t.message = status.Convert(err).Message()
+		return
+	}
+	t.message = ""
+}
+
+// call runs a request typed on the prompt, showing its error
+func (t *tui) call(request func(ctx context.Context) error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeOutContext)
+	defer cancel()
+	if err := request(ctx); err != nil {
+		t.message = status.Convert(err).Message()
+		return
+	}
+	t.message = ""
+}
+
+func (t *tui) draw() {
+	fmt.Fprint(t.out, ansiClear+t.view())
+}
+
+// view returns the screen: header, board with the movement list beside, status and prompt
+func (t *tui) view() string {
+	var b strings.Builder
+	connection := "connected"
+	if !t.connected {
+		connection = "disconnected"
+	}
+	fmt.Fprintf(&b, "%schess %s%s  [%s]\n\n", ansiBold, t.uuid, ansiReset, connection)
+
+	board := strings.Split(strings.TrimSuffix(t.game.Render(t.board), "\n"), "\n")
+	moves := moveListLines(t.moves, tuiMoveLines)
+	for i, line := range board {
+		b.WriteString("  " + line)
+		if i < len(moves) {
+			b.WriteString("    " + moves[i])
+		}
+		b.WriteString("\n")
+	}
+
+	gameStatus := t.status
+	if t.game.IsCheckBy(engine.Player{Color: oponentColor(t.game.Turn().Color)}) && !strings.HasPrefix(gameStatus, "game finished") {
+		gameStatus += ", check"
+	}
+	fmt.Fprintf(&b, "\n  Status: %s\n", gameStatus)
+	if t.clocks != "" {
+		fmt.Fprintf(&b, "  %s\n", t.clocks)
+	}
+	if t.takeback != "" {
+		fmt.Fprintf(&b, "  %s, type \"accept\" or \"decline\"\n", t.takeback)
+	} else if t.drawOffer != "" {
+		fmt.Fprintf(&b, "  %s, type \"accept\" or \"decline\"\n", t.drawOffer)
+	}
+	if t.message != "" {
+		fmt.Fprintf(&b, "  %s\n", t.message)
+	}
+	b.WriteString("  Type a move, \"takeback\", \"draw\", \"resign\", \"flip\" or \"quit\"\n> ")
+	return b.String()
+}
+
+// moveListLines returns the last movements numbered by move, one line per move, at most n lines
+func moveListLines(moves []string, n int) []string {
+	lines := []string{}
+	for i := 0; i < len(moves); i += 2 {
+		line := fmt.Sprintf("%3d. %-8s", i/2+1, moves[i])
+		if i+1 < len(moves) {
+			line += moves[i+1]
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return lines
+}
+
+// oponentColor returns the other color
+func oponentColor(color engine.Color) engine.Color {
+	if color == engine.WhiteColor {
+		return engine.BlackColor
+	}
+	return engine.WhiteColor
+}
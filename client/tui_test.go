@@ -0,0 +1,192 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"regexp"
+	"strings"
+	"testing"
+
+	pb "github.com/dumbogo/chess/api"
+	"github.com/dumbogo/chess/engine"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+var ansiRegexp = regexp.MustCompile("\x1b\\[[0-9;?]*[a-zA-Z]")
+
+// fakeChessClient answers Watch with responses, then closes the stream, and records Move, RespondDraw and RespondTakeback requests
+type fakeChessClient struct {
+	pb.ChessServiceClient
+	responses []*pb.WatchResponse
+	moves     []*pb.MoveRequest
+	draws     []*pb.RespondDrawRequest
+	takebacks []*pb.RespondTakebackRequest
+	// reconnected closed on the Watch call after the stream closed
+	reconnected chan struct{}
+}
+
+func (c *fakeChessClient) Watch(ctx context.Context, r *pb.WatchRequest, opts ...grpc.CallOption) (pb.ChessService_WatchClient, error) {
+	if len(c.responses) == 0 {
+		close(c.reconnected)
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	stream := &fakeWatchClient{responses: c.responses}
+	c.responses = nil
+	return stream, nil
+}
+
+func (c *fakeChessClient) Move(ctx context.Context, r *pb.MoveRequest, opts ...grpc.CallOption) (*pb.MoveResponse, error) {
+	c.moves = append(c.moves, r)
+	if r.GetSan() == "Ke2" {
+		return nil, errors.New("illegal SAN move \"Ke2\"")
+	}
+	return &pb.MoveResponse{}, nil
+}
+
+func (c *fakeChessClient) RespondDraw(ctx context.Context, r *pb.RespondDrawRequest, opts ...grpc.CallOption) (*pb.RespondDrawResponse, error) {
+	c.draws = append(c.draws, r)
+	return &pb.RespondDrawResponse{Status: "game finished, draw by agreement"}, nil
+}
+
+func (c *fakeChessClient) RespondTakeback(ctx context.Context, r *pb.RespondTakebackRequest, opts ...grpc.CallOption) (*pb.RespondTakebackResponse, error) {
+	c.takebacks = append(c.takebacks, r)
+	return &pb.RespondTakebackResponse{Status: "white turn"}, nil
+}
+
+func (c *fakeChessClient) Resign(ctx context.Context, r *pb.ResignRequest, opts ...grpc.CallOption) (*pb.ResignResponse, error) {
+	return nil, errors.New("game finished, draw by agreement")
+}
+
+type fakeWatchClient struct {
+	grpc.ClientStream
+	responses []*pb.WatchResponse
+}
+
+func (s *fakeWatchClient) Recv() (*pb.WatchResponse, error) {
+	if len(s.responses) == 0 {
+		return nil, io.EOF
+	}
+	r := s.responses[0]
+	s.responses = s.responses[1:]
+	return r, nil
+}
+
+func TestTUI(t *testing.T) {
+	assert := assert.New(t)
+	fen := "rnbqkbnr/pppp1ppp/8/4p3/4P3/8/PPPP1PPP/RNBQKBNR w KQkq e6 0 2"
+	client := &fakeChessClient{
+		responses: []*pb.WatchResponse{
+			{Status: "white turn", Fen: engine.StartingFEN},
+			{Status: "white turn", Fen: fen, Moves: []string{"e4", "e5"}},
+		},
+		reconnected: make(chan struct{}),
+	}
+	game, _ := engine.ParseFEN(engine.StartingFEN)
+	var out bytes.Buffer
+	tui := &tui{client: client, uuid: "someuuid", out: &out, board: engine.RenderOptions{Color: true, Coordinates: true, Flip: true}, game: game}
+
+	// the input is read once the stream updates were received and the stream closed
+	in, writer := io.Pipe()
+	go func() {
+		<-client.reconnected
+		io.WriteString(writer, "Ke2\ng1f3\nflip\nquit\n")
+	}()
+	assert.NoError(tui.run(in))
+
+	assert.Equal(fen, tui.game.FEN())
+	assert.Equal([]*pb.MoveRequest{{Uuid: "someuuid", San: "Ke2"}, {Uuid: "someuuid", FromSquare: "g1", ToSquare: "f3"}}, client.moves)
+	assert.False(tui.board.Flip)
+	screens := strings.Split(ansiRegexp.ReplaceAllString(out.String(), ""), "chess someuuid")
+	assert.Len(screens, 8)
+	assert.Contains(screens[3], "[connected]")
+	assert.Contains(screens[3], "  1. e4      e5")
+	assert.Contains(screens[4], "[disconnected]")
+	assert.Contains(screens[4], "connection lost, reconnecting: EOF")
+	assert.Contains(screens[5], "Status: white turn\n  illegal SAN move \"Ke2\"\n")
+	assert.NotContains(screens[6], "illegal SAN move")
+	assert.Contains(screens[6], "  1  R  N  B  K  Q  B  N  R ")
+	assert.Contains(screens[7], "  8  r  n  b  q  k  b  n  r ")
+	assert.Contains(screens[7], "     a  b  c  d  e  f  g  h\n")
+}
+
+func TestTUIDraw(t *testing.T) {
+	assert := assert.New(t)
+	client := &fakeChessClient{
+		responses:   []*pb.WatchResponse{{Status: "white turn", Fen: engine.StartingFEN, DrawOffer: true, DrawOfferColor: pb.Color_BLACK}},
+		reconnected: make(chan struct{}),
+	}
+	game, _ := engine.ParseFEN(engine.StartingFEN)
+	var out bytes.Buffer
+	tui := &tui{client: client, uuid: "someuuid", out: &out, game: game}
+
+	in, writer := io.Pipe()
+	go func() {
+		<-client.reconnected
+		io.WriteString(writer, "accept\nresign\nquit\n")
+	}()
+	assert.NoError(tui.run(in))
+
+	assert.Equal([]*pb.RespondDrawRequest{{Uuid: "someuuid", Accept: true}}, client.draws)
+	screens := strings.Split(ansiRegexp.ReplaceAllString(out.String(), ""), "chess someuuid")
+	assert.Contains(screens[2], "  Draw offered by black, type \"accept\" or \"decline\"\n")
+	assert.Contains(screens[len(screens)-1], "  game finished, draw by agreement\n")
+}
+
+func TestTUITakeback(t *testing.T) {
+	assert := assert.New(t)
+	client := &fakeChessClient{
+		responses: []*pb.WatchResponse{{
+			Status:               "white turn",
+			Fen:                  engine.StartingFEN,
+			DrawOffer:            true,
+			DrawOfferColor:       pb.Color_WHITE,
+			TakebackRequest:      true,
+			TakebackRequestColor: pb.Color_BLACK,
+		}},
+		reconnected: make(chan struct{}),
+	}
+	game, _ := engine.ParseFEN(engine.StartingFEN)
+	var out bytes.Buffer
+	tui := &tui{client: client, uuid: "someuuid", out: &out, game: game}
+
+	in, writer := io.Pipe()
+	go func() {
+		<-client.reconnected
+		io.WriteString(writer, "decline\nquit\n")
+	}()
+	assert.NoError(tui.run(in))
+
+	// the takeback is responded before the draw offered
+	assert.Equal([]*pb.RespondTakebackRequest{{Uuid: "someuuid"}}, client.takebacks)
+	assert.Empty(client.draws)
+	screens := strings.Split(ansiRegexp.ReplaceAllString(out.String(), ""), "chess someuuid")
+	assert.Contains(screens[2], "  Takeback requested by black, type \"accept\" or \"decline\"\n")
+	assert.NotContains(screens[2], "Draw offered")
+}
+
+func TestTUIReplay(t *testing.T) {
+	assert := assert.New(t)
+	game, moves := replay("rnbqkbnr/pppp1ppp/8/4p3/4P3/8/PPPP1PPP/RNBQKBNR w KQkq e6 0 2", []string{"e4", "e5"})
+	assert.Len(game.Movements(), 2)
+	assert.Equal([]string{"e4", "e5"}, moves)
+
+	// Case: moves not reaching the position
+	game, _ = replay("4k3/8/8/8/8/8/8/4K2R w K - 0 1", []string{"e4"})
+	assert.Len(game.Movements(), 0)
+	assert.Equal("4k3/8/8/8/8/8/8/4K2R w K - 0 1", game.FEN())
+
+	assert.Equal([]string{"  2. Nf3     Nc6", "  3. Bb5     "}, moveListLines([]string{"e4", "e5", "Nf3", "Nc6", "Bb5"}, 2))
+}
+
+func TestFormatClock(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal("0:00", formatClock(-5))
+	assert.Equal("4:59", formatClock(299999))
+	assert.Equal("1:05:00", formatClock(3900000))
+	assert.Equal("3d 2h", formatClock((74*3600+59)*1000))
+	assert.Equal("Clock: white 5:00, black 0:03", clocks(&pb.WatchResponse{Timed: true, WhiteTimeMs: 300000, BlackTimeMs: 3500}))
+}
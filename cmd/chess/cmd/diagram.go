@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dumbogo/chess/engine"
+	"github.com/dumbogo/chess/engine/diagram"
+	"github.com/dumbogo/chess/local"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(diagramCmd)
+
+	diagramCmd.Flags().StringVar(&diagramFEN, "fen", engine.StartingFEN, "Position in Forsyth-Edwards Notation")
+	diagramCmd.Flags().StringVar(&diagramGame, "load", "", "Game file whose last position is drawn, along with its last move, in PGN if it ends with .pgn, in FEN otherwise")
+	diagramCmd.Flags().StringVarP(&diagramOutput, "output", "o", "", "Image file, in PNG if it ends with .png, in SVG otherwise, SVG is written to the standard output if empty")
+	diagramCmd.Flags().IntVar(&diagramSize, "size", diagram.DefaultSize, "Image width and height in pixels")
+	diagramCmd.Flags().BoolVar(&diagramCoordinates, "coordinates", true, "Draw rank and file labels")
+	diagramCmd.Flags().StringSliceVar(&diagramHighlights, "highlight", nil, "Squares highlighted, i.e. --highlight e4,d5")
+	diagramCmd.Flags().StringSliceVar(&diagramArrows, "arrow", nil, "Arrows drawn between squares, i.e. --arrow e2e4,g1f3")
+}
+
+var (
+	diagramFEN         string
+	diagramGame        string
+	diagramOutput      string
+	diagramSize        int
+	diagramCoordinates bool
+	diagramHighlights  []string
+	diagramArrows      []string
+)
+
+var diagramCmd = &cobra.Command{
+	Use:   "diagram",
+	Short: "Draw a position as an image",
+	Long:  "Draw a position as an SVG or PNG image to share it, i.e. chess diagram --fen \"rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq e3 0 1\" --arrow e2e4 -o pos.svg",
+	Run: func(cmd *cobra.Command, args []string) {
+		game, err := engine.ParseFEN(diagramFEN)
+		if diagramGame != "" {
+			game, err = local.Load(diagramGame)
+		}
+		if err != nil {
+			log.Fatalf("Error: %v\n", err)
+		}
+		options := diagram.LastMove(game, diagram.Options{
+			Size:        diagramSize,
+			Flip:        boardFlip,
+			Coordinates: diagramCoordinates,
+		})
+		for _, s := range diagramHighlights {
+			loc, ok := engine.StringToSquareIdentifier(strings.ToUpper(s))
+			if !ok {
+				log.Fatalf("Invalid square %q", s)
+			}
+			options.Highlight = append(options.Highlight, loc)
+		}
+		for _, s := range diagramArrows {
+			var from, to engine.SquareIdentifier
+			ok := len(s) == 4
+			if ok {
+				from, ok = engine.StringToSquareIdentifier(strings.ToUpper(s[:2]))
+			}
+			if ok {
+				to, ok = engine.StringToSquareIdentifier(strings.ToUpper(s[2:]))
+			}
+			if !ok {
+				log.Fatalf("Invalid arrow %q, must be made of two squares, i.e. e2e4", s)
+			}
+			options.Arrows = append(options.Arrows, diagram.Arrow{From: from, To: to})
+		}
+
+		var w io.Writer = os.Stdout
+		format := "svg"
+		if diagramOutput != "" {
+			f, err := os.Create(diagramOutput)
+			if err != nil {
+				log.Fatalf("Error: %v\n", err)
+			}
+			defer f.Close()
+			w = f
+			if strings.EqualFold(filepath.Ext(diagramOutput), ".png") {
+				format = "png"
+			}
+		}
+		if err := diagram.Encode(w, format, game.Board(), options); err != nil {
+			log.Fatalf("Error: %v\n", err)
+		}
+	},
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"log"
+
+	"github.com/dumbogo/chess/client"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(drawCmd)
+	drawCmd.AddCommand(drawOfferCmd)
+	drawCmd.AddCommand(drawAcceptCmd)
+	drawCmd.AddCommand(drawDeclineCmd)
+}
+
+var drawCmd = &cobra.Command{
+	Use:   "draw",
+	Short: "Offer or respond a draw",
+	Long:  "Offer a draw to your oponent, or accept or decline the draw offered, i.e. chess draw offer",
+}
+
+var drawOfferCmd = &cobra.Command{
+	Use:   "offer",
+	Short: "Offer a draw",
+	Long:  "Offer a draw to your oponent on the current game, the offer is declined if your oponent moves",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		conn, err := client.InitConn()
+		if err != nil {
+			log.Fatalf("Error: %v\n", err)
+		}
+		defer conn.Close()
+		client.OfferDraw(conn)
+	},
+}
+
+var drawAcceptCmd = &cobra.Command{
+	Use:   "accept",
+	Short: "Accept the draw offered",
+	Long:  "Accept the draw offered by your oponent, the game finishes drawn",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		respondDraw(true)
+	},
+}
+
+var drawDeclineCmd = &cobra.Command{
+	Use:   "decline",
+	Short: "Decline the draw offered",
+	Long:  "Decline the draw offered by your oponent, the game goes on",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		respondDraw(false)
+	},
+}
+
+func respondDraw(accept bool) {
+	conn, err := client.InitConn()
+	if err != nil {
+		log.Fatalf("Error: %v\n", err)
+	}
+	defer conn.Close()
+	client.RespondDraw(conn, accept)
+}
@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"log"
+
+	"github.com/dumbogo/chess/client"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+	exportCmd.AddCommand(exportPGNCmd)
+}
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export game",
+	Long:  "Export a game to a chess notation format",
+}
+
+var exportPGNCmd = &cobra.Command{
+	Use:   "pgn [uuid]",
+	Short: "Export game to PGN",
+	Long:  "Export a game to Portable Game Notation, uses the current game if uuid is not provided, i.e. chess export pgn > game.pgn",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 1 {
+			uuid = args[0]
+		}
+		conn, err := client.InitConn()
+		if err != nil {
+			log.Fatalf("Error: %v\n", err)
+		}
+		defer conn.Close()
+		client.ExportPGN(conn, uuid)
+	},
+}
@@ -2,7 +2,9 @@ package cmd
 
 import (
 	"log"
+	"strings"
 
+	pb "github.com/dumbogo/chess/api"
 	"github.com/dumbogo/chess/client"
 	"github.com/spf13/cobra"
 )
@@ -12,25 +14,54 @@ func init() {
 
 	moveCmd.Flags().StringVarP(&from, "from", "f", "", "Square location from")
 	moveCmd.Flags().StringVarP(&to, "to", "t", "", "Square location to")
-	moveCmd.MarkFlagRequired("from")
-	moveCmd.MarkFlagRequired("to")
+	moveCmd.Flags().StringVarP(&promote, "promote", "p", "", "Piece to promote a pawn reaching the last rank: Q, R, B or N")
 }
 
 var (
-	from string
-	to   string
+	from    string
+	to      string
+	promote string
 )
 
+var promoteToPromotion = map[string]pb.Promotion{
+	"":  pb.Promotion_NO_PROMOTION,
+	"Q": pb.Promotion_QUEEN,
+	"R": pb.Promotion_ROOK,
+	"B": pb.Promotion_BISHOP,
+	"N": pb.Promotion_KNIGHT,
+}
+
 var moveCmd = &cobra.Command{
-	Use:   "move",
+	Use:   "move [from] [to] | move [san]",
 	Short: "Move piece",
-	Long:  "Move piece from square locations",
+	Long:  "Move piece from square locations, i.e. chess move e7 e8 --promote=N, or in Standard Algebraic Notation, i.e. chess move Nf3",
+	Args:  cobra.MaximumNArgs(2),
 	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 1 {
+			conn, err := client.InitConn()
+			if err != nil {
+				log.Fatalf("Error: %v\n", err)
+			}
+			defer conn.Close()
+			client.MoveSAN(conn, args[0])
+			return
+		}
+		if len(args) == 2 {
+			from, to = args[0], args[1]
+		}
+		if from == "" || to == "" {
+			log.Fatalf("Must define \"from\" and \"to\" squares")
+		}
+		promotion, ok := promoteToPromotion[strings.ToUpper(promote)]
+		if !ok {
+			log.Fatalf("Must define either \"Q\", \"R\", \"B\" or \"N\" to promote")
+		}
+
 		conn, err := client.InitConn()
 		if err != nil {
 			log.Fatalf("Error: %v\n", err)
 		}
 		defer conn.Close()
-		client.Move(conn, from, to)
+		client.Move(conn, from, to, promotion)
 	},
 }
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/dumbogo/chess/engine"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(perftCmd)
+
+	perftCmd.Flags().StringVar(&fen, "fen", engine.StartingFEN, "Position in Forsyth-Edwards Notation")
+	perftCmd.Flags().IntVarP(&depth, "depth", "d", 1, "Depth in plies")
+}
+
+var (
+	fen   string
+	depth int
+)
+
+var perftCmd = &cobra.Command{
+	Use:   "perft",
+	Short: "Count positions reached from a position",
+	Long:  "Count positions reached playing every legal move up to depth, printing them for each first move, i.e. chess perft --fen \"8/2p5/3p4/KP5r/1R3p1k/8/4P1P1/8 w - - 0 1\" --depth 3",
+	Run: func(cmd *cobra.Command, args []string) {
+		game, err := engine.ParseFENWithBoard(fen, engine.LoadBitboard)
+		if err != nil {
+			log.Fatalf("Error: %v\n", err)
+		}
+		if depth < 1 {
+			log.Fatalf("Depth must be at least 1")
+		}
+		divide := game.Divide(depth)
+		moves := make([]string, 0, len(divide))
+		var nodes uint64
+		for m, n := range divide {
+			moves = append(moves, fmt.Sprintf("%s: %d", m, n))
+			nodes += n
+		}
+		sort.Strings(moves)
+		for _, m := range moves {
+			fmt.Println(m)
+		}
+		fmt.Printf("\nNodes searched: %d\n", nodes)
+	},
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"log"
+	"os"
+
+	"github.com/dumbogo/chess/engine"
+	"github.com/dumbogo/chess/engine/search"
+	"github.com/dumbogo/chess/local"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(playCmd)
+
+	playCmd.Flags().BoolVar(&playLocal, "local", false, "Play in this terminal without the chess server")
+	playCmd.Flags().StringVar(&playComputer, "computer", "", "Color played by the computer, either \"white\" or \"black\", two players share the terminal if empty")
+	playCmd.Flags().IntVarP(&playLevel, "level", "l", search.DefaultLevel, "Computer strength from 1 to 8")
+	playCmd.Flags().StringVar(&playFile, "load", "", "Game file to continue, in PGN if it ends with .pgn, in FEN otherwise")
+}
+
+var (
+	playLocal    bool
+	playComputer string
+	playLevel    int
+	playFile     string
+)
+
+var playCmd = &cobra.Command{
+	Use:   "play",
+	Short: "Play a local game",
+	Long:  "Play a game in the terminal without the chess server, between two players or against the computer, i.e. chess play --local --computer black --level 3",
+	Run: func(cmd *cobra.Command, args []string) {
+		if !playLocal {
+			log.Fatalf("Only local games are played with play, use --local, or start and join for games on the server")
+		}
+		var options local.Options
+		var err error
+		switch playComputer {
+		case "":
+		case "white":
+			options.Computer = engine.WhiteColor
+		case "black":
+			options.Computer = engine.BlackColor
+		default:
+			log.Fatalf("Computer must play either \"white\" or \"black\"")
+		}
+		if playLevel < 1 || playLevel > search.MaxLevel {
+			log.Fatalf("Level must be between 1 and %d", search.MaxLevel)
+		}
+		options.Level = playLevel
+		if options.Board, err = boardOptions(); err != nil {
+			log.Fatalf("Error: %v\n", err)
+		}
+
+		game := local.NewGame(options)
+		if playFile != "" {
+			if game, err = local.Load(playFile); err != nil {
+				log.Fatalf("Error: %v\n", err)
+			}
+		}
+		if err := local.NewSession(game, options, os.Stdin, os.Stdout).Run(); err != nil {
+			log.Fatalf("Error: %v\n", err)
+		}
+	},
+}
@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"log"
+
+	"github.com/dumbogo/chess/client"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(resignCmd)
+}
+
+var resignCmd = &cobra.Command{
+	Use:   "resign",
+	Short: "Resign game",
+	Long:  "Resign the current game, your oponent wins",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		conn, err := client.InitConn()
+		if err != nil {
+			log.Fatalf("Error: %v\n", err)
+		}
+		defer conn.Close()
+		client.Resign(conn)
+	},
+}
@@ -1,13 +1,52 @@
 package cmd
 
 import (
+	"fmt"
+
+	"github.com/dumbogo/chess/client"
+	"github.com/dumbogo/chess/engine"
 	"github.com/spf13/cobra"
 )
 
+func init() {
+	rootCmd.PersistentFlags().StringVar(&boardStyle, "board", "unicode", "Board style: \"ascii\", \"unicode\" or \"color\"")
+	rootCmd.PersistentFlags().BoolVar(&boardFlip, "flip", false, "Draw the board from the other side")
+}
+
+var (
+	boardStyle string
+	boardFlip  bool
+)
+
+// boardStyles render options of each board style
+var boardStyles = map[string]engine.RenderOptions{
+	"ascii":   {Coordinates: true},
+	"unicode": {Unicode: true, Coordinates: true},
+	"color":   {Unicode: true, Color: true, Coordinates: true},
+}
+
 var rootCmd = &cobra.Command{
 	Use:   "chess",
 	Short: "Chess game",
 	Long:  "Chess multi-player game on terminal",
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		options, err := boardOptions()
+		if err != nil {
+			return err
+		}
+		client.SetBoardOptions(options)
+		return nil
+	},
+}
+
+// boardOptions returns the render options of the board flags
+func boardOptions() (engine.RenderOptions, error) {
+	options, ok := boardStyles[boardStyle]
+	if !ok {
+		return options, fmt.Errorf("Board style must be either \"ascii\", \"unicode\" or \"color\"")
+	}
+	options.Flip = boardFlip
+	return options, nil
 }
 
 // Execute executes root command
@@ -1,7 +1,10 @@
 package cmd
 
 import (
+	"fmt"
 	"log"
+	"strconv"
+	"strings"
 
 	pb "github.com/dumbogo/chess/api"
 	"github.com/dumbogo/chess/client"
@@ -13,25 +16,28 @@ func init() {
 
 	startCmd.Flags().StringVarP(&name, "name", "n", "", "Game name")
 	startCmd.Flags().StringVarP(&color, "color", "c", "white", "Color to chose")
+	startCmd.Flags().BoolVar(&computer, "computer", false, "Play against the computer")
+	startCmd.Flags().Uint32VarP(&level, "level", "l", 0, "Computer strength from 1 to 8, default level when 0")
+	startCmd.Flags().StringVarP(&timeControl, "time", "t", "", "Time control as minutes per player plus seconds added on each move, i.e. 5+3, the game is not timed if empty")
+	startCmd.Flags().Uint32Var(&daysPerMove, "days", 0, "Days per move for correspondence games")
 	startCmd.MarkFlagRequired("name")
 }
 
 var (
-	name  string
-	color string
+	name     string
+	color    string
+	computer bool
+	level    uint32
+
+	timeControl string
+	daysPerMove uint32
 )
 
 var startCmd = &cobra.Command{
 	Use:   "start",
 	Short: "start game",
-	Long:  "Start a new game",
+	Long:  "Start a new game, i.e. chess start -n foo --computer --level 3 --time 10+5",
 	Run: func(cmd *cobra.Command, args []string) {
-		conn, err := client.InitConn()
-		if err != nil {
-			log.Fatalf("Error: %v\n", err)
-		}
-		defer conn.Close()
-
 		inputColor := pb.Color_WHITE
 		switch color {
 		case "white":
@@ -40,6 +46,45 @@ var startCmd = &cobra.Command{
 		default:
 			log.Fatalf("Must define either \"white\" or \"black\" color")
 		}
-		client.StartGame(conn, name, inputColor)
+		opponent := pb.Opponent_HUMAN
+		if computer {
+			opponent = pb.Opponent_COMPUTER
+		}
+		tc, err := parseTimeControl(timeControl, daysPerMove)
+		if err != nil {
+			log.Fatalf("Error: %v\n", err)
+		}
+
+		conn, err := client.InitConn()
+		if err != nil {
+			log.Fatalf("Error: %v\n", err)
+		}
+		defer conn.Close()
+		client.StartGame(conn, name, inputColor, opponent, level, tc)
 	},
 }
+
+// parseTimeControl returns the time control of "minutes+increment" or days per move, nil when neither is set
+func parseTimeControl(s string, days uint32) (*pb.TimeControl, error) {
+	if s == "" && days == 0 {
+		return nil, nil
+	}
+	if s != "" && days > 0 {
+		return nil, fmt.Errorf("Must define either time or days per move")
+	}
+	if days > 0 {
+		return &pb.TimeControl{DaysPerMove: days}, nil
+	}
+	parts := strings.SplitN(s, "+", 2)
+	minutes, err := strconv.ParseFloat(parts[0], 64)
+	if err != nil || minutes <= 0 {
+		return nil, fmt.Errorf("Invalid time %q, must be minutes plus increment seconds, i.e. 5+3", s)
+	}
+	var increment uint64
+	if len(parts) == 2 {
+		if increment, err = strconv.ParseUint(parts[1], 10, 32); err != nil {
+			return nil, fmt.Errorf("Invalid time %q, must be minutes plus increment seconds, i.e. 5+3", s)
+		}
+	}
+	return &pb.TimeControl{BaseSeconds: uint32(minutes * 60), IncrementSeconds: uint32(increment)}, nil
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"log"
+
+	"github.com/dumbogo/chess/client"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(takebackCmd)
+	takebackCmd.AddCommand(takebackRequestCmd)
+	takebackCmd.AddCommand(takebackAcceptCmd)
+	takebackCmd.AddCommand(takebackDeclineCmd)
+}
+
+var takebackCmd = &cobra.Command{
+	Use:   "takeback",
+	Short: "Request or respond a takeback",
+	Long:  "Ask your oponent to take back your last movement, or accept or decline the takeback requested, i.e. chess takeback request",
+}
+
+var takebackRequestCmd = &cobra.Command{
+	Use:   "request",
+	Short: "Request a takeback",
+	Long:  "Ask your oponent to take back your last movement on the current game, along with its reply to it if made, the computer accepts it on your turn",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		conn, err := client.InitConn()
+		if err != nil {
+			log.Fatalf("Error: %v\n", err)
+		}
+		defer conn.Close()
+		client.RequestTakeback(conn)
+	},
+}
+
+var takebackAcceptCmd = &cobra.Command{
+	Use:   "accept",
+	Short: "Accept the takeback requested",
+	Long:  "Accept the takeback requested by your oponent, its last movement is undone",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		respondTakeback(true)
+	},
+}
+
+var takebackDeclineCmd = &cobra.Command{
+	Use:   "decline",
+	Short: "Decline the takeback requested",
+	Long:  "Decline the takeback requested by your oponent, the game goes on",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		respondTakeback(false)
+	},
+}
+
+func respondTakeback(accept bool) {
+	conn, err := client.InitConn()
+	if err != nil {
+		log.Fatalf("Error: %v\n", err)
+	}
+	defer conn.Close()
+	client.RespondTakeback(conn, accept)
+}
@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"log"
+	"os"
+
+	"github.com/dumbogo/chess/client"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(tuiCmd)
+	tuiCmd.Flags().StringVarP(&uuid, "uuid", "I", "", "uuid game")
+}
+
+var tuiCmd = &cobra.Command{
+	Use:   "tui",
+	Short: "Play game on an interactive terminal",
+	Long:  "Play the current game on a full-screen terminal, the board is updated live and moves are typed on the prompt",
+	Run: func(cmd *cobra.Command, args []string) {
+		conn, err := client.InitConn()
+		if err != nil {
+			log.Fatalf("Error: %v\n", err)
+		}
+		defer conn.Close()
+		if err := client.TUI(conn, uuid, os.Stdin, os.Stdout); err != nil {
+			log.Fatalf("Error: %v\n", err)
+		}
+	},
+}
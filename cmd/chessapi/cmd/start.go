@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"crypto/tls"
 	"fmt"
 	"log"
@@ -75,6 +76,9 @@ var startCmd = &cobra.Command{
 			Db: db,
 		})
 
+		// Finish games timed out or abandoned, and play the computer turns left pending
+		go api.Sweep(context.Background(), configuration.SweepInterval, configuration.AbandonAfter)
+
 		// Load HTTP server
 		go func() {
 			s, err := api.NewHTTPServer(
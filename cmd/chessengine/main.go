@@ -0,0 +1,14 @@
+package main
+
+import (
+	"log"
+	"os"
+
+	"github.com/dumbogo/chess/uci"
+)
+
+func main() {
+	if err := uci.NewServer(os.Stdin, os.Stdout).Run(); err != nil {
+		log.Fatalf("Error: %v\n", err)
+	}
+}
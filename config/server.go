@@ -3,6 +3,7 @@ package config
 import (
 	"log"
 	"path/filepath"
+	"time"
 
 	"github.com/dumbogo/chess/api"
 	"github.com/spf13/viper"
@@ -12,6 +13,12 @@ var (
 	configFileType = "toml"
 )
 
+// Sweeper defaults, when not set
+const (
+	defaultSweepInterval = 10 * time.Second
+	defaultAbandonAfter  = 7 * 24 * time.Hour
+)
+
 // ServerConfig server configuration
 type ServerConfig struct {
 	ENV     string
@@ -32,6 +39,10 @@ type ServerConfig struct {
 	HTTPServerHost   string // HTTP_server.Host
 	HTTPServerPort   string // HTTP_server.Port
 
+	// Sweeper finishing games timed out or abandoned
+	SweepInterval time.Duration // Sweeper.interval
+	AbandonAfter  time.Duration // Sweeper.abandon_after, untimed games are never abandoned when "0s"
+
 	// Sensitive config
 	DBUser     string // CHESS_API_DATABASE_USERNAME env
 	DBPassword string // CHESS_API_DATABASE_PASSWORD env
@@ -81,6 +92,15 @@ func LoadServerConfig(configFile string) (*ServerConfig, error) {
 	c.HTTPServerHost = v.GetString("HTTP_server.Host")
 	c.HTTPServerPort = v.GetString("HTTP_server.Port")
 
+	c.SweepInterval = v.GetDuration("Sweeper.interval")
+	if c.SweepInterval <= 0 {
+		c.SweepInterval = defaultSweepInterval
+	}
+	c.AbandonAfter = defaultAbandonAfter
+	if v.IsSet("Sweeper.abandon_after") {
+		c.AbandonAfter = v.GetDuration("Sweeper.abandon_after")
+	}
+
 	// TODO: Set ENVS as mandatory
 	v.SetEnvPrefix("CHESS_API")
 	v.AllowEmptyEnv(false) // This doesn't work as expected
@@ -0,0 +1,271 @@
+package engine
+
+import "math/bits"
+
+// BoardLoader creates a Board with the pieces placed in squares, i.e. LoadBoard or LoadBitboard
+type BoardLoader func(whitePlayer, blackPlayer *Player, squares Squares) Board
+
+// fastBoard is a Board answering attacks, movements and king lookups without ranging over every square
+type fastBoard interface {
+	// square returns the square loc, without copying every square as Squares does
+	square(loc SquareIdentifier) Square
+	// attacked returns true if any piece of color attacks loc
+	attacked(loc SquareIdentifier, color Color) bool
+	// kingSquare returns the square of the king of color, false if there is none
+	kingSquare(color Color) (SquareIdentifier, bool)
+	// moves returns the squares the piece in from moves to, castling excluded and regardless of
+	// its king safety, enPassant is the en passant target square, 0 if none
+	moves(from, enPassant SquareIdentifier) uint64
+}
+
+// bitboard Board keeping a 64 bits set of squares for each color and piece,
+// bit 0 is A1, bit 7 is H1 and bit 63 is H8
+type bitboard struct {
+	whitePlayer *Player
+	blackPlayer *Player
+	squares     Squares
+
+	pieces [WhiteColor + 1][KingIdentifier + 1]uint64
+	colors [WhiteColor + 1]uint64
+}
+
+// rays directions, the first four ones increase the square index
+const (
+	northRay = iota
+	eastRay
+	northEastRay
+	northWestRay
+	southRay
+	westRay
+	southWestRay
+	southEastRay
+)
+
+// raySteps coordinate step of each ray direction
+var raySteps = [southEastRay + 1]Coordinate{{0, 1}, {1, 0}, {1, 1}, {255, 1}, {0, 255}, {255, 0}, {255, 255}, {1, 255}}
+
+var (
+	rookRays   = []int{northRay, eastRay, southRay, westRay}
+	bishopRays = []int{northEastRay, northWestRay, southWestRay, southEastRay}
+)
+
+var (
+	knightAttacks [64]uint64
+	kingAttacks   [64]uint64
+	// pawnAttacksFrom squares attacked by a pawn of color placed in the square
+	pawnAttacksFrom [WhiteColor + 1][64]uint64
+	// rays squares in a direction from a square up to the board edge
+	rays [southEastRay + 1][64]uint64
+)
+
+func init() {
+	for sq := 0; sq < 64; sq++ {
+		from := SquareIdentifierToCoordinate(SquareIdentifier(sq + 1))
+		for _, step := range knightSteps {
+			knightAttacks[sq] |= stepBit(from, step)
+		}
+		for _, step := range kingSteps {
+			kingAttacks[sq] |= stepBit(from, step)
+		}
+		pawnAttacksFrom[WhiteColor][sq] = stepBit(from, raySteps[northEastRay]) | stepBit(from, raySteps[northWestRay])
+		pawnAttacksFrom[BlackColor][sq] = stepBit(from, raySteps[southEastRay]) | stepBit(from, raySteps[southWestRay])
+		for direction, step := range raySteps {
+			for to := from; stepBit(to, step) != 0; {
+				to = Coordinate{X: to.X + step.X, Y: to.Y + step.Y}
+				rays[direction][sq] |= squareBit(CoordinateToSquareIdentifier(to))
+			}
+		}
+	}
+}
+
+// squareBit returns the bit of loc
+func squareBit(loc SquareIdentifier) uint64 {
+	return 1 << (loc - 1)
+}
+
+// stepBit returns the bit of the square reached from a coordinate with step, 0 if out of the board
+func stepBit(from Coordinate, step Coordinate) uint64 {
+	to := Coordinate{X: from.X + step.X, Y: from.Y + step.Y}
+	if to.X > MAXX || to.Y > MAXY {
+		return 0
+	}
+	return squareBit(CoordinateToSquareIdentifier(to))
+}
+
+// slidingAttacks returns squares attacked from sq in directions, stopping on the first occupied square
+func slidingAttacks(sq int, occupied uint64, directions []int) uint64 {
+	var attacks uint64
+	for _, direction := range directions {
+		ray := rays[direction][sq]
+		attacks |= ray
+		blockers := ray & occupied
+		if blockers == 0 {
+			continue
+		}
+		var blocker int
+		if direction < southRay {
+			blocker = bits.TrailingZeros64(blockers)
+		} else {
+			blocker = 63 - bits.LeadingZeros64(blockers)
+		}
+		attacks &^= rays[direction][blocker]
+	}
+	return attacks
+}
+
+// NewBitboard creates a new bitboard Board to play
+func NewBitboard(whitePlayer, blackPlayer *Player) Board {
+	return LoadBitboard(whitePlayer, blackPlayer, PristineSquares())
+}
+
+// LoadBitboard loads a bitboard Board, squares are copied
+func LoadBitboard(whitePlayer, blackPlayer *Player, squares Squares) Board {
+	b := &bitboard{
+		whitePlayer: whitePlayer,
+		blackPlayer: blackPlayer,
+		squares:     Squares{},
+	}
+	for loc, square := range squares {
+		b.squares[loc] = square
+		if !square.Empty {
+			b.setBit(loc, square.Piece)
+		}
+	}
+	return b
+}
+
+// Squares returns a copy of the squares, changed only through EatPiece and FillSquare to keep the bits along
+func (b *bitboard) Squares() Squares {
+	squares := make(Squares, len(b.squares))
+	for loc, square := range b.squares {
+		squares[loc] = square
+	}
+	return squares
+}
+
+func (b *bitboard) WhitePlayer() *Player {
+	return b.whitePlayer
+}
+
+func (b *bitboard) BlackPlayer() *Player {
+	return b.blackPlayer
+}
+
+func (b *bitboard) EatPiece(loc SquareIdentifier) Piece {
+	square := b.squares[loc]
+	piece := square.Piece
+	if piece != nil {
+		b.clearBit(loc, piece)
+	}
+	square.Piece = nil
+	square.Empty = true
+	b.squares[loc] = square
+	return piece
+}
+
+func (b *bitboard) FillSquare(loc SquareIdentifier, piece Piece) {
+	square := b.squares[loc]
+	if square.Piece != nil {
+		b.clearBit(loc, square.Piece)
+	}
+	square.Piece = piece
+	square.Empty = piece == nil
+	if piece != nil {
+		b.setBit(loc, piece)
+	}
+	b.squares[loc] = square
+}
+
+func (b *bitboard) Render(options RenderOptions) string {
+	return renderSquares(b.squares, options)
+}
+
+func (b *bitboard) String() string {
+	return squaresString(b.squares)
+}
+
+func (b *bitboard) setBit(loc SquareIdentifier, piece Piece) {
+	b.pieces[piece.Color()][piece.Identifier()] |= squareBit(loc)
+	b.colors[piece.Color()] |= squareBit(loc)
+}
+
+func (b *bitboard) clearBit(loc SquareIdentifier, piece Piece) {
+	b.pieces[piece.Color()][piece.Identifier()] &^= squareBit(loc)
+	b.colors[piece.Color()] &^= squareBit(loc)
+}
+
+func (b *bitboard) attacked(loc SquareIdentifier, color Color) bool {
+	sq := int(loc - 1)
+	pieces := b.pieces[color]
+	oponent := WhiteColor
+	if color == WhiteColor {
+		oponent = BlackColor
+	}
+	occupied := b.colors[WhiteColor] | b.colors[BlackColor]
+	return knightAttacks[sq]&pieces[KnightIdentifier] != 0 ||
+		kingAttacks[sq]&pieces[KingIdentifier] != 0 ||
+		// a pawn of color attacks loc from the squares an oponent pawn in loc would attack
+		pawnAttacksFrom[oponent][sq]&pieces[PawnIdentifier] != 0 ||
+		slidingAttacks(sq, occupied, rookRays)&(pieces[RookIdentifier]|pieces[QueenIdentifier]) != 0 ||
+		slidingAttacks(sq, occupied, bishopRays)&(pieces[BishopIdentifier]|pieces[QueenIdentifier]) != 0
+}
+
+func (b *bitboard) kingSquare(color Color) (SquareIdentifier, bool) {
+	kings := b.pieces[color][KingIdentifier]
+	if kings == 0 {
+		return 0, false
+	}
+	return SquareIdentifier(bits.TrailingZeros64(kings) + 1), true
+}
+
+func (b *bitboard) square(loc SquareIdentifier) Square {
+	return b.squares[loc]
+}
+
+func (b *bitboard) moves(from, enPassant SquareIdentifier) uint64 {
+	square := b.squares[from]
+	if square.Empty {
+		return 0
+	}
+	sq := int(from - 1)
+	color, oponent := square.Piece.Color(), WhiteColor
+	if color == WhiteColor {
+		oponent = BlackColor
+	}
+	occupied := b.colors[WhiteColor] | b.colors[BlackColor]
+	var moves uint64
+	switch square.Piece.Identifier() {
+	case KnightIdentifier:
+		moves = knightAttacks[sq]
+	case KingIdentifier:
+		moves = kingAttacks[sq]
+	case RookIdentifier:
+		moves = slidingAttacks(sq, occupied, rookRays)
+	case BishopIdentifier:
+		moves = slidingAttacks(sq, occupied, bishopRays)
+	case QueenIdentifier:
+		moves = slidingAttacks(sq, occupied, rookRays) | slidingAttacks(sq, occupied, bishopRays)
+	case PawnIdentifier:
+		captures := b.colors[oponent]
+		// the target is passed by an oponent pawn, on the 6th rank for white and on the 3rd one for black
+		if enPassant != 0 && (color == WhiteColor) == (SquareIdentifierToCoordinate(enPassant).Y == 5) {
+			captures |= squareBit(enPassant)
+		}
+		moves = pawnAttacksFrom[color][sq] & captures
+		// one square forward if empty, two from the starting rank if both are empty
+		var push uint64
+		if color == WhiteColor {
+			push = squareBit(from) << 8 &^ occupied
+			if sq/8 == 1 {
+				push |= push << 8 &^ occupied
+			}
+		} else {
+			push = squareBit(from) >> 8 &^ occupied
+			if sq/8 == 6 {
+				push |= push >> 8 &^ occupied
+			}
+		}
+		return moves | push
+	}
+	return moves &^ b.colors[color]
+}
@@ -0,0 +1,123 @@
+package engine
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBitboard(t *testing.T) {
+	assert := assert.New(t)
+	board := NewBitboard(&p1, &p2)
+	mapBoard := testableBoard()
+	assert.Equal("Hugo", board.WhitePlayer().Name)
+	assert.Equal("Pedro", board.BlackPlayer().Name)
+	assert.Equal(mapBoard.Squares(), board.Squares())
+	assert.Equal(mapBoard.String(), board.String())
+
+	piece := board.EatPiece(H7)
+	assert.Equal(NewPawn(BlackColor), piece)
+	assert.True(board.Squares()[H7].Empty)
+	assert.Nil(board.Squares()[H7].Piece)
+	assert.Nil(board.EatPiece(H6))
+
+	board.FillSquare(D5, NewQueen(WhiteColor))
+	board.FillSquare(D5, NewKnight(WhiteColor))
+	assert.Equal(Square{Piece: NewKnight(WhiteColor), Coordinates: Coordinate{3, 4}, SquareIdentifier: D5}, board.Squares()[D5])
+	board.FillSquare(D5, nil)
+	assert.True(board.Squares()[D5].Empty)
+
+	loc, ok := board.(*bitboard).kingSquare(BlackColor)
+	assert.True(ok)
+	assert.Equal(E8, loc)
+	board.EatPiece(E8)
+	_, ok = board.(*bitboard).kingSquare(BlackColor)
+	assert.False(ok)
+
+	// squares are copied when loaded
+	squares := PristineSquares()
+	board = LoadBitboard(&p1, &p2, squares)
+	board.EatPiece(A1)
+	assert.False(squares[A1].Empty)
+
+	// squares returned are copies, only EatPiece and FillSquare change them along with the bits
+	board.Squares()[B1] = Square{Empty: true, SquareIdentifier: B1}
+	assert.Equal(NewKnight(WhiteColor), board.Squares()[B1].Piece)
+
+	// movements, en passant captures and double steps from the starting rank included
+	game, _ := ParseFENWithBoard("rnbqkbnr/ppp1p1pp/8/3pPp2/8/8/PPPP1PPP/RNBQKBNR w KQkq f6 0 3", LoadBitboard)
+	b := game.Board().(*bitboard)
+	assert.Equal(squareBit(E6)|squareBit(F6), b.moves(E5, F6))
+	assert.Equal(squareBit(E6), b.moves(E5, 0))
+	assert.Equal(squareBit(D3)|squareBit(D4), b.moves(D2, F6))
+	assert.Equal(squareBit(A3)|squareBit(C3), b.moves(B1, 0))
+	assert.Equal(squareBit(E2), b.moves(E1, 0))
+	assert.Equal(uint64(0), b.moves(E4, 0))
+}
+
+func TestBitboardAttacked(t *testing.T) {
+	assert := assert.New(t)
+	for _, fen := range []string{
+		StartingFEN,
+		"r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1",
+		"8/2p5/3p4/KP5r/1R3p1k/8/4P1P1/8 w - - 0 1",
+		"r3k2r/Pppp1ppp/1b3nbN/nP6/BBP1P3/q4N2/Pp1P2PP/R2Q1RK1 w kq - 0 1",
+	} {
+		game, _ := ParseFEN(fen)
+		squares := game.Board().Squares()
+		board := LoadBitboard(&p1, &p2, squares).(*bitboard)
+		for loc := A1; loc <= H8; loc++ {
+			for _, color := range []Color{WhiteColor, BlackColor} {
+				oponent := WhiteColor
+				if color == WhiteColor {
+					oponent = BlackColor
+				}
+				assert.Equal(
+					kingEatableInSquare(oponent, game.Board(), game.Movements(), squares[loc]),
+					board.attacked(loc, color),
+					"%s %s attacked by %d", fen, SquareIdentifierToString(loc), color,
+				)
+			}
+		}
+	}
+}
+
+// boardLoaders Board implementations the game tests run on
+var boardLoaders = map[string]BoardLoader{
+	"board":    LoadBoard,
+	"bitboard": LoadBitboard,
+}
+
+// runBoardLoaders runs test on each Board implementation
+func runBoardLoaders(t *testing.T, test func(*testing.T, BoardLoader)) {
+	for name, loader := range boardLoaders {
+		loader := loader
+		t.Run(name, func(t *testing.T) { test(t, loader) })
+	}
+}
+
+// TestBoardLoaders plays random games on both boards
+func TestBoardLoaders(t *testing.T) {
+	assert := assert.New(t)
+	random := rand.New(rand.NewSource(1))
+	for _, fen := range []string{
+		StartingFEN,
+		"r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1",
+		"8/2p5/3p4/KP5r/1R3p1k/8/4P1P1/8 w - - 0 1",
+	} {
+		mapGame, _ := ParseFENWithBoard(fen, LoadBoard)
+		bitboardGame, _ := ParseFENWithBoard(fen, LoadBitboard)
+		for ply := 0; ply < 60 && mapGame.Status().Result == OngoingResult; ply++ {
+			moves := mapGame.LegalMoves()
+			assert.Equal(moves, bitboardGame.LegalMoves(), mapGame.FEN())
+			m := moves[random.Intn(len(moves))]
+			mapGame.Move(mapGame.Turn(), m.From, m.To, m.Promotion)
+			bitboardGame.Move(bitboardGame.Turn(), m.From, m.To, m.Promotion)
+			assert.Equal(mapGame.FEN(), bitboardGame.FEN())
+			assert.Equal(mapGame.Status(), bitboardGame.Status())
+			assert.Equal(mapGame.IsCheckBy(mapGame.Turn()), bitboardGame.IsCheckBy(bitboardGame.Turn()))
+		}
+		assert.Equal(mapGame.PGN(nil), bitboardGame.PGN(nil))
+	}
+}
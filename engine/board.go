@@ -21,6 +21,8 @@ type Board interface {
 	EatPiece(loc SquareIdentifier) Piece
 	// Fill a square with a piece
 	FillSquare(SquareIdentifier, Piece)
+	// Render returns the board drawn as text lines, see RenderOptions
+	Render(options RenderOptions) string
 	String() string
 }
 
@@ -80,17 +82,26 @@ func (b *board) FillSquare(loc SquareIdentifier, piece Piece) {
 	b.squares[loc] = square
 }
 
+func (b *board) Render(options RenderOptions) string {
+	return renderSquares(b.squares, options)
+}
+
 func (b *board) String() string {
+	return squaresString(b.squares)
+}
+
+// squaresString renders squares as a table
+func squaresString(squares Squares) string {
 	var builder strings.Builder
 
-	a1, b1, c1, d1, e1, f1, g1, h1 := b.squares[A1], b.squares[B1], b.squares[C1], b.squares[D1], b.squares[E1], b.squares[F1], b.squares[G1], b.squares[H1]
-	a2, b2, c2, d2, e2, f2, g2, h2 := b.squares[A2], b.squares[B2], b.squares[C2], b.squares[D2], b.squares[E2], b.squares[F2], b.squares[G2], b.squares[H2]
-	a3, b3, c3, d3, e3, f3, g3, h3 := b.squares[A3], b.squares[B3], b.squares[C3], b.squares[D3], b.squares[E3], b.squares[F3], b.squares[G3], b.squares[H3]
-	a4, b4, c4, d4, e4, f4, g4, h4 := b.squares[A4], b.squares[B4], b.squares[C4], b.squares[D4], b.squares[E4], b.squares[F4], b.squares[G4], b.squares[H4]
-	a5, b5, c5, d5, e5, f5, g5, h5 := b.squares[A5], b.squares[B5], b.squares[C5], b.squares[D5], b.squares[E5], b.squares[F5], b.squares[G5], b.squares[H5]
-	a6, b6, c6, d6, e6, f6, g6, h6 := b.squares[A6], b.squares[B6], b.squares[C6], b.squares[D6], b.squares[E6], b.squares[F6], b.squares[G6], b.squares[H6]
-	a7, b7, c7, d7, e7, f7, g7, h7 := b.squares[A7], b.squares[B7], b.squares[C7], b.squares[D7], b.squares[E7], b.squares[F7], b.squares[G7], b.squares[H7]
-	a8, b8, c8, d8, e8, f8, g8, h8 := b.squares[A8], b.squares[B8], b.squares[C8], b.squares[D8], b.squares[E8], b.squares[F8], b.squares[G8], b.squares[H8]
+	a1, b1, c1, d1, e1, f1, g1, h1 := squares[A1], squares[B1], squares[C1], squares[D1], squares[E1], squares[F1], squares[G1], squares[H1]
+	a2, b2, c2, d2, e2, f2, g2, h2 := squares[A2], squares[B2], squares[C2], squares[D2], squares[E2], squares[F2], squares[G2], squares[H2]
+	a3, b3, c3, d3, e3, f3, g3, h3 := squares[A3], squares[B3], squares[C3], squares[D3], squares[E3], squares[F3], squares[G3], squares[H3]
+	a4, b4, c4, d4, e4, f4, g4, h4 := squares[A4], squares[B4], squares[C4], squares[D4], squares[E4], squares[F4], squares[G4], squares[H4]
+	a5, b5, c5, d5, e5, f5, g5, h5 := squares[A5], squares[B5], squares[C5], squares[D5], squares[E5], squares[F5], squares[G5], squares[H5]
+	a6, b6, c6, d6, e6, f6, g6, h6 := squares[A6], squares[B6], squares[C6], squares[D6], squares[E6], squares[F6], squares[G6], squares[H6]
+	a7, b7, c7, d7, e7, f7, g7, h7 := squares[A7], squares[B7], squares[C7], squares[D7], squares[E7], squares[F7], squares[G7], squares[H7]
+	a8, b8, c8, d8, e8, f8, g8, h8 := squares[A8], squares[B8], squares[C8], squares[D8], squares[E8], squares[F8], squares[G8], squares[H8]
 
 	data := [][]string{
 		{"8", a8.String(), b8.String(), c8.String(), d8.String(), e8.String(), f8.String(), g8.String(), h8.String()},
@@ -73,6 +73,20 @@ func (mr *MockBoardMockRecorder) FillSquare(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FillSquare", reflect.TypeOf((*MockBoard)(nil).FillSquare), arg0, arg1)
 }
 
+// Render mocks base method.
+func (m *MockBoard) Render(options RenderOptions) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Render", options)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Render indicates an expected call of Render.
+func (mr *MockBoardMockRecorder) Render(options interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Render", reflect.TypeOf((*MockBoard)(nil).Render), options)
+}
+
 // Squares mocks base method.
 func (m *MockBoard) Squares() Squares {
 	m.ctrl.T.Helper()
@@ -125,3 +125,10 @@ func StringToSquareIdentifier(str string) (SquareIdentifier, bool) {
 	}
 	return v, false
 }
+
+// SquareIdentifierToString returns string from SquareIdentifier.
+// i.e. str = F(A1) = "A1"
+func SquareIdentifierToString(i SquareIdentifier) string {
+	c := SquareIdentifierToCoordinate(i)
+	return string([]byte{'A' + c.X, '1' + c.Y})
+}
@@ -0,0 +1,342 @@
+// Package diagram draws board diagrams as SVG and PNG images, to share positions outside the terminal
+package diagram
+
+import (
+	"fmt"
+	"image/color"
+	"io"
+	"math"
+
+	"github.com/dumbogo/chess/engine"
+)
+
+// DefaultSize image width and height in pixels when Options.Size is not set
+const DefaultSize = 480
+
+// Diagram geometry, in units of a square 100 units wide, scaled to the image size
+const (
+	squareUnits = 100
+	boardUnits  = 8 * squareUnits
+	strokeWidth = 3
+	arrowWidth  = 14
+	arrowHead   = 40
+	// glyphUnits side of a coordinate glyph bit
+	glyphUnits = 3
+	// labelMargin distance from the coordinate labels to the square edges
+	labelMargin = 4
+)
+
+var (
+	lightSquare = color.NRGBA{0xf0, 0xd9, 0xb5, 0xff}
+	darkSquare  = color.NRGBA{0xb5, 0x88, 0x63, 0xff}
+	highlight   = color.NRGBA{0x9b, 0xc7, 0x00, 0x69}
+	arrowColor  = color.NRGBA{0x15, 0x78, 0x1b, 0xcc}
+	whiteFill   = color.NRGBA{0xff, 0xff, 0xff, 0xff}
+	blackFill   = color.NRGBA{0x22, 0x22, 0x22, 0xff}
+	pieceStroke = color.NRGBA{0x00, 0x00, 0x00, 0xff}
+)
+
+// Options diagram options
+type Options struct {
+	// Size image width and height in pixels, DefaultSize when 0
+	Size int
+	// Flip draws the board from black side
+	Flip bool
+	// Coordinates draws rank and file labels on the edge squares
+	Coordinates bool
+	// Highlight squares highlighted, i.e. the last movement squares
+	Highlight []engine.SquareIdentifier
+	// Arrows arrows drawn between squares, i.e. the last movement
+	Arrows []Arrow
+}
+
+// Arrow an arrow drawn from a square to another
+type Arrow struct {
+	From engine.SquareIdentifier
+	To   engine.SquareIdentifier
+}
+
+// LastMove returns options highlighting the squares of the last movement of game, with an arrow between them
+func LastMove(game engine.Game, options Options) Options {
+	movements := game.Movements()
+	if len(movements) == 0 {
+		return options
+	}
+	last := movements[len(movements)-1]
+	options.Highlight = append(options.Highlight, last.From, last.To)
+	options.Arrows = append(options.Arrows, Arrow{From: last.From, To: last.To})
+	return options
+}
+
+// Encode writes the diagram of board in format, either "svg" or "png"
+func Encode(w io.Writer, format string, board engine.Board, options Options) error {
+	switch format {
+	case "svg":
+		return SVG(w, board, options)
+	case "png":
+		return PNG(w, board, options)
+	}
+	return fmt.Errorf("unknown diagram format %q, must be either \"svg\" or \"png\"", format)
+}
+
+type point struct {
+	X, Y float64
+}
+
+// shape a polygon, or a circle when radius is not 0
+type shape struct {
+	points []point
+	center point
+	radius float64
+	// min and max corners of the rectangle enclosing the shape
+	min, max point
+}
+
+func polygon(coords ...float64) shape {
+	points := make([]point, len(coords)/2)
+	for i := range points {
+		points[i] = point{coords[2*i], coords[2*i+1]}
+	}
+	return newPolygon(points)
+}
+
+func newPolygon(points []point) shape {
+	s := shape{points: points, min: points[0], max: points[0]}
+	for _, p := range points {
+		s.min = point{math.Min(s.min.X, p.X), math.Min(s.min.Y, p.Y)}
+		s.max = point{math.Max(s.max.X, p.X), math.Max(s.max.Y, p.Y)}
+	}
+	return s
+}
+
+func circle(x, y, radius float64) shape {
+	return shape{center: point{x, y}, radius: radius, min: point{x - radius, y - radius}, max: point{x + radius, y + radius}}
+}
+
+// outside returns true if p is farther than d from the rectangle enclosing the shape
+func (s shape) outside(p point, d float64) bool {
+	return p.X < s.min.X-d || p.X > s.max.X+d || p.Y < s.min.Y-d || p.Y > s.max.Y+d
+}
+
+// contains returns true if p is inside the shape, polygons use the even-odd rule
+func (s shape) contains(p point) bool {
+	if s.outside(p, 0) {
+		return false
+	}
+	if s.radius > 0 {
+		return math.Hypot(p.X-s.center.X, p.Y-s.center.Y) < s.radius
+	}
+	inside := false
+	for i, j := 0, len(s.points)-1; i < len(s.points); j, i = i, i+1 {
+		a, b := s.points[i], s.points[j]
+		if (a.Y > p.Y) != (b.Y > p.Y) && p.X < (b.X-a.X)*(p.Y-a.Y)/(b.Y-a.Y)+a.X {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// near returns true if p is within d of the shape outline
+func (s shape) near(p point, d float64) bool {
+	if s.outside(p, d) {
+		return false
+	}
+	if s.radius > 0 {
+		return math.Abs(math.Hypot(p.X-s.center.X, p.Y-s.center.Y)-s.radius) <= d
+	}
+	for i, j := 0, len(s.points)-1; i < len(s.points); j, i = i, i+1 {
+		if segmentNear(p, s.points[j], s.points[i], d) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s shape) translate(dx, dy float64) shape {
+	if s.radius > 0 {
+		return circle(s.center.X+dx, s.center.Y+dy, s.radius)
+	}
+	points := make([]point, len(s.points))
+	for i, p := range s.points {
+		points[i] = point{p.X + dx, p.Y + dy}
+	}
+	return newPolygon(points)
+}
+
+// segmentNear returns true if p is within d of the segment from a to b
+func segmentNear(p, a, b point, d float64) bool {
+	if (p.X < a.X-d && p.X < b.X-d) || (p.X > a.X+d && p.X > b.X+d) ||
+		(p.Y < a.Y-d && p.Y < b.Y-d) || (p.Y > a.Y+d && p.Y > b.Y+d) {
+		return false
+	}
+	dx, dy := b.X-a.X, b.Y-a.Y
+	t := 0.0
+	if length := dx*dx + dy*dy; length > 0 {
+		t = math.Max(0, math.Min(1, ((p.X-a.X)*dx+(p.Y-a.Y)*dy)/length))
+	}
+	ex, ey := p.X-(a.X+t*dx), p.Y-(a.Y+t*dy)
+	return ex*ex+ey*ey <= d*d
+}
+
+var pieceBase = polygon(24, 80, 76, 80, 76, 88, 24, 88)
+
+// pieceShapes piece silhouettes within a square, drawn in order, filled and outlined
+var pieceShapes = map[engine.PieceIdentifier][]shape{
+	engine.PawnIdentifier: {
+		polygon(40, 42, 60, 42, 67, 80, 33, 80),
+		circle(50, 32, 12),
+		pieceBase,
+	},
+	engine.KnightIdentifier: {
+		polygon(70, 80, 72, 52, 66, 32, 56, 20, 52, 12, 46, 20, 34, 30, 24, 46, 28, 54, 38, 52, 48, 48, 36, 66, 34, 80),
+		pieceBase,
+	},
+	engine.BishopIdentifier: {
+		polygon(42, 58, 58, 58, 66, 80, 34, 80),
+		polygon(50, 20, 60, 32, 64, 44, 58, 58, 42, 58, 36, 44, 40, 32),
+		circle(50, 16, 6),
+		pieceBase,
+	},
+	engine.RookIdentifier: {
+		polygon(34, 38, 66, 38, 66, 80, 34, 80),
+		polygon(28, 18, 37, 18, 37, 26, 45, 26, 45, 18, 55, 18, 55, 26, 63, 26, 63, 18, 72, 18, 72, 38, 28, 38),
+		pieceBase,
+	},
+	engine.QueenIdentifier: {
+		polygon(22, 30, 34, 58, 38, 24, 46, 54, 50, 20, 54, 54, 62, 24, 66, 58, 78, 30, 68, 80, 32, 80),
+		circle(22, 28, 6), circle(38, 22, 6), circle(50, 18, 6), circle(62, 22, 6), circle(78, 28, 6),
+		pieceBase,
+	},
+	engine.KingIdentifier: {
+		polygon(46, 8, 54, 8, 54, 16, 62, 16, 62, 24, 54, 24, 54, 34, 46, 34, 46, 24, 38, 24, 38, 16, 46, 16),
+		polygon(28, 36, 72, 36, 64, 80, 36, 80),
+		pieceBase,
+	},
+}
+
+// glyphs bitmaps of the coordinate labels, 5 bits wide and 7 high
+var glyphs = map[rune][7]string{
+	'1': {"..#..", ".##..", "..#..", "..#..", "..#..", "..#..", ".###."},
+	'2': {".###.", "#...#", "....#", "...#.", "..#..", ".#...", "#####"},
+	'3': {"#####", "...#.", "..#..", "...#.", "....#", "#...#", ".###."},
+	'4': {"...#.", "..##.", ".#.#.", "#..#.", "#####", "...#.", "...#."},
+	'5': {"#####", "#....", "####.", "....#", "....#", "#...#", ".###."},
+	'6': {"..##.", ".#...", "#....", "####.", "#...#", "#...#", ".###."},
+	'7': {"#####", "....#", "...#.", "..#..", ".#...", ".#...", ".#..."},
+	'8': {".###.", "#...#", "#...#", ".###.", "#...#", "#...#", ".###."},
+	'a': {".....", ".....", ".###.", "....#", ".####", "#...#", ".####"},
+	'b': {"#....", "#....", "#.##.", "##..#", "#...#", "#...#", "####."},
+	'c': {".....", ".....", ".###.", "#....", "#....", "#...#", ".###."},
+	'd': {"....#", "....#", ".##.#", "#..##", "#...#", "#...#", ".####"},
+	'e': {".....", ".....", ".###.", "#...#", "#####", "#....", ".###."},
+	'f': {"..##.", ".#..#", ".#...", "###..", ".#...", ".#...", ".#..."},
+	'g': {".....", ".####", "#...#", "#...#", ".####", "....#", ".###."},
+	'h': {"#....", "#....", "#.##.", "##..#", "#...#", "#...#", "#...#"},
+}
+
+// label a coordinate label, drawn with the color of the squares of the other color
+type label struct {
+	text  rune
+	at    point
+	color color.NRGBA
+}
+
+// layout the elements of a diagram in board units, shared by the SVG and PNG encoders
+type layout struct {
+	size       int
+	squares    []square
+	highlights []point
+	labels     []label
+	pieces     []piece
+	arrows     []shape
+}
+
+type square struct {
+	at    point
+	color color.NRGBA
+}
+
+type piece struct {
+	shapes []shape
+	fill   color.NRGBA
+}
+
+func newLayout(board engine.Board, options Options) layout {
+	l := layout{size: options.Size}
+	if l.size <= 0 {
+		l.size = DefaultSize
+	}
+	origin := func(loc engine.SquareIdentifier) point {
+		c := engine.SquareIdentifierToCoordinate(loc)
+		col, row := float64(c.X), float64(7-c.Y)
+		if options.Flip {
+			col, row = 7-col, 7-row
+		}
+		return point{col * squareUnits, row * squareUnits}
+	}
+
+	squares := board.Squares()
+	for loc := engine.A1; loc <= engine.H8; loc++ {
+		at := origin(loc)
+		c := engine.SquareIdentifierToCoordinate(loc)
+		background, foreground := darkSquare, lightSquare
+		if (c.X+c.Y)%2 == 1 {
+			background, foreground = lightSquare, darkSquare
+		}
+		l.squares = append(l.squares, square{at: at, color: background})
+		if options.Coordinates && at.X == 0 {
+			l.labels = append(l.labels, label{text: rune('1' + c.Y), at: point{at.X + labelMargin, at.Y + labelMargin}, color: foreground})
+		}
+		if options.Coordinates && at.Y == 7*squareUnits {
+			l.labels = append(l.labels, label{
+				text:  rune('a' + c.X),
+				at:    point{at.X + squareUnits - labelMargin - 5*glyphUnits, at.Y + squareUnits - labelMargin - 7*glyphUnits},
+				color: foreground,
+			})
+		}
+		if s := squares[loc]; !s.Empty {
+			p := piece{fill: whiteFill}
+			if s.Piece.Color() == engine.BlackColor {
+				p.fill = blackFill
+			}
+			for _, sh := range pieceShapes[s.Piece.Identifier()] {
+				p.shapes = append(p.shapes, sh.translate(at.X, at.Y))
+			}
+			l.pieces = append(l.pieces, p)
+		}
+	}
+	for _, loc := range options.Highlight {
+		l.highlights = append(l.highlights, origin(loc))
+	}
+	for _, a := range options.Arrows {
+		if a.From == a.To {
+			continue
+		}
+		from, to := origin(a.From), origin(a.To)
+		l.arrows = append(l.arrows, arrow(
+			point{from.X + squareUnits/2, from.Y + squareUnits/2},
+			point{to.X + squareUnits/2, to.Y + squareUnits/2},
+		))
+	}
+	return l
+}
+
+// arrow returns the polygon of an arrow from a to b, its head ending at b
+func arrow(a, b point) shape {
+	length := math.Hypot(b.X-a.X, b.Y-a.Y)
+	dx, dy := (b.X-a.X)/length, (b.Y-a.Y)/length
+	nx, ny := -dy, dx
+	neck := point{b.X - dx*arrowHead, b.Y - dy*arrowHead}
+	at := func(p point, n float64) point {
+		return point{p.X + nx*n, p.Y + ny*n}
+	}
+	return newPolygon([]point{
+		at(a, arrowWidth/2),
+		at(neck, arrowWidth/2),
+		at(neck, arrowHead/2),
+		b,
+		at(neck, -arrowHead/2),
+		at(neck, -arrowWidth/2),
+		at(a, -arrowWidth/2),
+	})
+}
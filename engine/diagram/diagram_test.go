@@ -0,0 +1,81 @@
+package diagram
+
+import (
+	"bytes"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/dumbogo/chess/engine"
+	"github.com/stretchr/testify/assert"
+)
+
+func testGame(t *testing.T) engine.Game {
+	game, _ := engine.ParseFEN(engine.StartingFEN)
+	m, err := game.ParseSAN("e4")
+	assert.NoError(t, err)
+	game.Move(game.Turn(), m.From, m.To, m.Promotion)
+	return game
+}
+
+func TestLastMove(t *testing.T) {
+	assert := assert.New(t)
+	game := testGame(t)
+	options := LastMove(game, Options{Flip: true})
+	assert.True(options.Flip)
+	assert.Equal([]engine.SquareIdentifier{engine.E2, engine.E4}, options.Highlight)
+	assert.Equal([]Arrow{{From: engine.E2, To: engine.E4}}, options.Arrows)
+
+	game, _ = engine.ParseFEN(engine.StartingFEN)
+	assert.Equal(Options{}, LastMove(game, Options{}))
+}
+
+func TestSVG(t *testing.T) {
+	assert := assert.New(t)
+	game := testGame(t)
+	var b bytes.Buffer
+	assert.NoError(SVG(&b, game.Board(), LastMove(game, Options{Size: 240, Coordinates: true})))
+	svg := b.String()
+	assert.True(strings.HasPrefix(svg, `<svg xmlns="http://www.w3.org/2000/svg" width="240" height="240" viewBox="0 0 800 800">`))
+	assert.True(strings.HasSuffix(svg, "</svg>\n"))
+	assert.Contains(svg, `<rect x="0" y="700" width="100" height="100" fill="#b58863"/>`)
+	assert.Contains(svg, `<rect x="400" y="600" width="100" height="100" fill="#9bc700" fill-opacity="0.41"/>`)
+	assert.Equal(66, strings.Count(svg, "<rect "))
+	assert.Equal(16, strings.Count(svg, "<text "))
+	assert.Equal(32, strings.Count(svg, "<g "))
+	assert.Equal(16, strings.Count(svg, `<g fill="#ffffff"`))
+	assert.Contains(svg, `fill="#15781b" fill-opacity="0.80"/>`)
+
+	// Case: flipped, a1 on the top right corner
+	b.Reset()
+	assert.NoError(SVG(&b, game.Board(), Options{Flip: true}))
+	assert.Contains(b.String(), `<rect x="700" y="0" width="100" height="100" fill="#b58863"/>`)
+	assert.NotContains(b.String(), "<text ")
+}
+
+func TestPNG(t *testing.T) {
+	assert := assert.New(t)
+	game := testGame(t)
+	var b bytes.Buffer
+	assert.NoError(Encode(&b, "png", game.Board(), LastMove(game, Options{Size: 400})))
+	img, err := png.Decode(&b)
+	assert.NoError(err)
+	assert.Equal(400, img.Bounds().Dx())
+	assert.Equal(400, img.Bounds().Dy())
+
+	at := func(loc engine.SquareIdentifier, x, y int) color.Color {
+		c := engine.SquareIdentifierToCoordinate(loc)
+		return color.NRGBAModel.Convert(img.At(int(c.X)*50+x, (7-int(c.Y))*50+y))
+	}
+	assert.Equal(darkSquare, at(engine.A1, 1, 1))
+	assert.Equal(lightSquare, at(engine.H1, 1, 1))
+	assert.Equal(color.NRGBA{0xcd, 0xd2, 0x6a, 0xff}, at(engine.E4, 1, 1))
+	assert.Equal(whiteFill, at(engine.E1, 25, 30))
+	assert.Equal(blackFill, at(engine.E8, 25, 30))
+	// rook base outline, antialiased
+	assert.Less(at(engine.A1, 12, 42).(color.NRGBA).R, uint8(0x80))
+	assert.Equal(whiteFill, at(engine.A1, 14, 42))
+
+	assert.Error(Encode(&b, "gif", game.Board(), Options{}))
+}
@@ -0,0 +1,142 @@
+package diagram
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+
+	"github.com/dumbogo/chess/engine"
+)
+
+// samples points sampled along each pixel side to antialias edges
+const samples = 4
+
+// PNG writes the diagram of board as a PNG image
+func PNG(w io.Writer, board engine.Board, options Options) error {
+	l := newLayout(board, options)
+	c := canvas{
+		img:   image.NewNRGBA(image.Rect(0, 0, l.size, l.size)),
+		scale: float64(l.size) / boardUnits,
+	}
+	for _, s := range l.squares {
+		c.paintSquare(s.at, s.color)
+	}
+	for _, at := range l.highlights {
+		c.paintSquare(at, highlight)
+	}
+	for _, lb := range l.labels {
+		lb := lb
+		bitmap := glyphs[lb.text]
+		c.paint(lb.at, point{lb.at.X + 5*glyphUnits, lb.at.Y + 7*glyphUnits}, func(p point) (color.NRGBA, bool) {
+			x, y := int((p.X-lb.at.X)/glyphUnits), int((p.Y-lb.at.Y)/glyphUnits)
+			return lb.color, x >= 0 && x < 5 && y >= 0 && y < 7 && bitmap[y][x] == '#'
+		})
+	}
+	for _, pc := range l.pieces {
+		pc := pc
+		min, max := bounds(pc.shapes, strokeWidth/2.0)
+		c.paint(min, max, func(p point) (color.NRGBA, bool) {
+			// shapes drawn last cover the previous ones
+			for i := len(pc.shapes) - 1; i >= 0; i-- {
+				switch s := pc.shapes[i]; {
+				case s.near(p, strokeWidth/2.0):
+					return pieceStroke, true
+				case s.contains(p):
+					return pc.fill, true
+				}
+			}
+			return color.NRGBA{}, false
+		})
+	}
+	for _, a := range l.arrows {
+		a := a
+		min, max := a.min, a.max
+		c.paint(min, max, func(p point) (color.NRGBA, bool) {
+			return arrowColor, a.contains(p)
+		})
+	}
+	return png.Encode(w, c.img)
+}
+
+// canvas an image drawn in board units
+type canvas struct {
+	img *image.NRGBA
+	// scale pixels per board unit
+	scale float64
+}
+
+// paintSquare blends col over the square at, its edges rounded to pixels so squares are not antialiased
+func (c *canvas) paintSquare(at point, col color.NRGBA) {
+	x0, y0 := int(math.Round(at.X*c.scale)), int(math.Round(at.Y*c.scale))
+	x1, y1 := int(math.Round((at.X+squareUnits)*c.scale)), int(math.Round((at.Y+squareUnits)*c.scale))
+	a := float64(col.A) / 0xff
+	blend := func(top, bottom uint8) uint8 {
+		return uint8(math.Round(float64(top)*a + float64(bottom)*(1-a)))
+	}
+	for py := y0; py < y1; py++ {
+		for px := x0; px < x1; px++ {
+			base := c.img.NRGBAAt(px, py)
+			c.img.SetNRGBA(px, py, color.NRGBA{blend(col.R, base.R), blend(col.G, base.G), blend(col.B, base.B), 0xff})
+		}
+	}
+}
+
+// paint blends the colors sampled within the area from min to max over the image,
+// sample returns false for points it does not cover
+func (c *canvas) paint(min, max point, sample func(point) (color.NRGBA, bool)) {
+	bounds := c.img.Bounds()
+	x0, y0 := int(math.Floor(min.X*c.scale)), int(math.Floor(min.Y*c.scale))
+	x1, y1 := int(math.Ceil(max.X*c.scale)), int(math.Ceil(max.Y*c.scale))
+	if x0 < bounds.Min.X {
+		x0 = bounds.Min.X
+	}
+	if y0 < bounds.Min.Y {
+		y0 = bounds.Min.Y
+	}
+	if x1 > bounds.Max.X {
+		x1 = bounds.Max.X
+	}
+	if y1 > bounds.Max.Y {
+		y1 = bounds.Max.Y
+	}
+	for py := y0; py < y1; py++ {
+		for px := x0; px < x1; px++ {
+			base := c.img.NRGBAAt(px, py)
+			var r, g, b float64
+			covered := false
+			for sy := 0; sy < samples; sy++ {
+				for sx := 0; sx < samples; sx++ {
+					p := point{
+						(float64(px) + (float64(sx)+0.5)/samples) / c.scale,
+						(float64(py) + (float64(sy)+0.5)/samples) / c.scale,
+					}
+					col, ok := sample(p)
+					if !ok {
+						col.A = 0
+					}
+					covered = covered || ok
+					a := float64(col.A) / 0xff
+					r += float64(col.R)*a + float64(base.R)*(1-a)
+					g += float64(col.G)*a + float64(base.G)*(1-a)
+					b += float64(col.B)*a + float64(base.B)*(1-a)
+				}
+			}
+			if covered {
+				n := float64(samples * samples)
+				c.img.SetNRGBA(px, py, color.NRGBA{uint8(math.Round(r / n)), uint8(math.Round(g / n)), uint8(math.Round(b / n)), 0xff})
+			}
+		}
+	}
+}
+
+// bounds returns the corners of the rectangle enclosing shapes, widened by margin
+func bounds(shapes []shape, margin float64) (point, point) {
+	min, max := shapes[0].min, shapes[0].max
+	for _, s := range shapes {
+		min = point{math.Min(min.X, s.min.X), math.Min(min.Y, s.min.Y)}
+		max = point{math.Max(max.X, s.max.X), math.Max(max.Y, s.max.Y)}
+	}
+	return point{min.X - margin, min.Y - margin}, point{max.X + margin, max.Y + margin}
+}
@@ -0,0 +1,63 @@
+package diagram
+
+import (
+	"bufio"
+	"fmt"
+	"image/color"
+	"io"
+	"strings"
+
+	"github.com/dumbogo/chess/engine"
+)
+
+// SVG writes the diagram of board as an SVG image
+func SVG(w io.Writer, board engine.Board, options Options) error {
+	l := newLayout(board, options)
+	b := bufio.NewWriter(w)
+	fmt.Fprintf(b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n", l.size, l.size, boardUnits, boardUnits)
+	for _, s := range l.squares {
+		fmt.Fprintf(b, `<rect x="%g" y="%g" width="%d" height="%d" fill="%s"/>`+"\n", s.at.X, s.at.Y, squareUnits, squareUnits, hex(s.color))
+	}
+	for _, at := range l.highlights {
+		fmt.Fprintf(b, `<rect x="%g" y="%g" width="%d" height="%d" %s/>`+"\n", at.X, at.Y, squareUnits, squareUnits, fill(highlight))
+	}
+	for _, lb := range l.labels {
+		fmt.Fprintf(b, `<text x="%g" y="%g" font-family="sans-serif" font-size="%d" font-weight="bold" fill="%s">%c</text>`+"\n",
+			lb.at.X, lb.at.Y+7*glyphUnits, 8*glyphUnits, hex(lb.color), lb.text)
+	}
+	for _, p := range l.pieces {
+		fmt.Fprintf(b, `<g fill="%s" stroke="%s" stroke-width="%d" stroke-linejoin="round">`+"\n", hex(p.fill), hex(pieceStroke), strokeWidth)
+		for _, s := range p.shapes {
+			b.WriteString(svgShape(s, "") + "\n")
+		}
+		b.WriteString("</g>\n")
+	}
+	for _, a := range l.arrows {
+		b.WriteString(svgShape(a, fill(arrowColor)) + "\n")
+	}
+	b.WriteString("</svg>\n")
+	return b.Flush()
+}
+
+func svgShape(s shape, attributes string) string {
+	if attributes != "" {
+		attributes = " " + attributes
+	}
+	if s.radius > 0 {
+		return fmt.Sprintf(`<circle cx="%g" cy="%g" r="%g"%s/>`, s.center.X, s.center.Y, s.radius, attributes)
+	}
+	points := make([]string, len(s.points))
+	for i, p := range s.points {
+		points[i] = fmt.Sprintf("%.1f,%.1f", p.X, p.Y)
+	}
+	return fmt.Sprintf(`<polygon points="%s"%s/>`, strings.Join(points, " "), attributes)
+}
+
+// fill returns the fill attributes of a translucent color
+func fill(c color.NRGBA) string {
+	return fmt.Sprintf(`fill="%s" fill-opacity="%.2f"`, hex(c), float64(c.A)/0xff)
+}
+
+func hex(c color.NRGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
@@ -0,0 +1,316 @@
+package engine
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// StartingFEN FEN of the initial game position
+const StartingFEN = "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1"
+
+var fenPieces = map[PieceIdentifier]byte{
+	PawnIdentifier:   'p',
+	KnightIdentifier: 'n',
+	BishopIdentifier: 'b',
+	RookIdentifier:   'r',
+	QueenIdentifier:  'q',
+	KingIdentifier:   'k',
+}
+
+var fenCastlingRights = []struct {
+	letter byte
+	right  uint8
+}{
+	{'K', whiteKingSideCastling},
+	{'Q', whiteQueenSideCastling},
+	{'k', blackKingSideCastling},
+	{'q', blackQueenSideCastling},
+}
+
+// ParseFEN creates a Game from a Forsyth-Edwards Notation string,
+// i.e. "rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq e3 0 1"
+func ParseFEN(fen string) (Game, error) {
+	return ParseFENWithBoard(fen, LoadBoard)
+}
+
+// ParseFENWithBoard creates a Game from a Forsyth-Edwards Notation string played on the Board created by loader
+func ParseFENWithBoard(fen string, loader BoardLoader) (Game, error) {
+	fields := strings.Fields(fen)
+	if len(fields) != 6 {
+		return nil, fmt.Errorf("invalid FEN: expected 6 fields, got %d", len(fields))
+	}
+
+	white := Player{Color: WhiteColor}
+	black := Player{Color: BlackColor}
+	squares, err := parseFENPlacement(fields[0])
+	if err != nil {
+		return nil, err
+	}
+	g := &game{
+		board:       loader(&white, &black, squares),
+		white:       white,
+		black:       black,
+		whitePieces: PiecesList{},
+		blackPieces: PiecesList{},
+		movements:   []Movement{},
+	}
+	for _, square := range squares {
+		if !square.Empty {
+			g.addPiecePlayer(square.Piece)
+		}
+	}
+	if g.whitePieces[KingIdentifier] != 1 || g.blackPieces[KingIdentifier] != 1 {
+		return nil, fmt.Errorf("invalid FEN piece placement %q: each player must have exactly one king", fields[0])
+	}
+	for _, loc := range []SquareIdentifier{A1, B1, C1, D1, E1, F1, G1, H1, A8, B8, C8, D8, E8, F8, G8, H8} {
+		if !squares[loc].Empty && squares[loc].Piece.Identifier() == PawnIdentifier {
+			return nil, fmt.Errorf("invalid FEN piece placement %q: pawn in first or last rank", fields[0])
+		}
+	}
+
+	switch fields[1] {
+	case "w":
+		g.turn = white
+	case "b":
+		g.turn = black
+	default:
+		return nil, fmt.Errorf("invalid FEN side to move %q: must be either \"w\" or \"b\"", fields[1])
+	}
+	if g.IsCheckBy(g.turn) {
+		return nil, fmt.Errorf("invalid FEN: player not in turn is in check")
+	}
+
+	if g.castlingRights, err = parseFENCastling(fields[2], squares); err != nil {
+		return nil, err
+	}
+	if g.enPassant, err = parseFENEnPassant(fields[3], g.turn.Color, squares); err != nil {
+		return nil, err
+	}
+
+	if g.initialHalfmoveClock, err = strconv.Atoi(fields[4]); err != nil || g.initialHalfmoveClock < 0 {
+		return nil, fmt.Errorf("invalid FEN halfmove clock %q: must be a non negative number", fields[4])
+	}
+	if g.initialFullmoveNumber, err = strconv.Atoi(fields[5]); err != nil || g.initialFullmoveNumber < 1 {
+		return nil, fmt.Errorf("invalid FEN fullmove number %q: must be a positive number", fields[5])
+	}
+	g.initHash()
+	return g, nil
+}
+
+func parseFENPlacement(placement string) (Squares, error) {
+	ranks := strings.Split(placement, "/")
+	if len(ranks) != 8 {
+		return nil, fmt.Errorf("invalid FEN piece placement %q: expected 8 ranks, got %d", placement, len(ranks))
+	}
+	squares := Squares{}
+	for i, rank := range ranks {
+		y := uint8(MAXY - i)
+		x := uint8(0)
+		for _, c := range []byte(rank) {
+			if c >= '1' && c <= '8' {
+				for n := byte(0); n < c-'0' && x <= MAXX; n++ {
+					loc := CoordinateToSquareIdentifier(Coordinate{X: x, Y: y})
+					squares[loc] = Square{Empty: true, Coordinates: Coordinate{X: x, Y: y}, SquareIdentifier: loc}
+					x++
+				}
+				continue
+			}
+			piece := fenToPiece(c)
+			if piece == nil {
+				return nil, fmt.Errorf("invalid FEN piece placement %q: unknown piece %q", placement, c)
+			}
+			if x > MAXX {
+				break
+			}
+			loc := CoordinateToSquareIdentifier(Coordinate{X: x, Y: y})
+			squares[loc] = Square{Piece: piece, Coordinates: Coordinate{X: x, Y: y}, SquareIdentifier: loc}
+			x++
+		}
+		if fileCount := fenRankFiles(rank); fileCount != 8 {
+			return nil, fmt.Errorf("invalid FEN piece placement %q: rank %d has %d files", placement, y+1, fileCount)
+		}
+	}
+	return squares, nil
+}
+
+// fenRankFiles returns number of files described by a FEN rank
+func fenRankFiles(rank string) int {
+	files := 0
+	for _, c := range []byte(rank) {
+		if c >= '1' && c <= '8' {
+			files += int(c - '0')
+		} else {
+			files++
+		}
+	}
+	return files
+}
+
+func parseFENCastling(field string, squares Squares) (uint8, error) {
+	if field == "-" {
+		return 0, nil
+	}
+	var rights uint8
+	for _, c := range []byte(field) {
+		found := false
+		for _, r := range fenCastlingRights {
+			if r.letter != c || rights&r.right != 0 {
+				continue
+			}
+			found = true
+			rights |= r.right
+			for _, cas := range castlings {
+				if cas.right != r.right {
+					continue
+				}
+				king, rook := squares[cas.kingFrom], squares[cas.rookFrom]
+				if king.Empty || king.Piece.Identifier() != KingIdentifier || king.Piece.Color() != cas.color ||
+					rook.Empty || rook.Piece.Identifier() != RookIdentifier || rook.Piece.Color() != cas.color {
+					return 0, fmt.Errorf("invalid FEN castling rights %q: king or rook not in place for %q", field, c)
+				}
+			}
+		}
+		if !found {
+			return 0, fmt.Errorf("invalid FEN castling rights %q: unexpected %q", field, c)
+		}
+	}
+	return rights, nil
+}
+
+func parseFENEnPassant(field string, turn Color, squares Squares) (SquareIdentifier, error) {
+	if field == "-" {
+		return 0, nil
+	}
+	loc, ok := StringToSquareIdentifier(strings.ToUpper(field))
+	if !ok {
+		return 0, fmt.Errorf("invalid FEN en passant target %q: unknown square", field)
+	}
+	target := SquareIdentifierToCoordinate(loc)
+	// pawn which advanced two squares is in front of the target
+	targetY, pawnY, color := uint8(2), uint8(3), WhiteColor
+	if turn == WhiteColor {
+		targetY, pawnY, color = 5, 4, BlackColor
+	}
+	if target.Y != targetY {
+		return 0, fmt.Errorf("invalid FEN en passant target %q: wrong rank for the player in turn", field)
+	}
+	pawn := squares[CoordinateToSquareIdentifier(Coordinate{X: target.X, Y: pawnY})]
+	if pawn.Empty || pawn.Piece.Identifier() != PawnIdentifier || pawn.Piece.Color() != color || !squares[loc].Empty {
+		return 0, fmt.Errorf("invalid FEN en passant target %q: no pawn advanced two squares", field)
+	}
+	return loc, nil
+}
+
+func fenToPiece(c byte) Piece {
+	color := WhiteColor
+	lower := c
+	if c >= 'a' && c <= 'z' {
+		color = BlackColor
+	} else {
+		lower = c + 'a' - 'A'
+	}
+	for identifier, letter := range fenPieces {
+		if letter == lower {
+			return PieceFromPieceIdentifier(identifier, color)
+		}
+	}
+	return nil
+}
+
+func pieceToFEN(p Piece) byte {
+	letter := fenPieces[p.Identifier()]
+	if p.Color() == WhiteColor {
+		return letter - 'a' + 'A'
+	}
+	return letter
+}
+
+func (g *game) FEN() string {
+	var builder strings.Builder
+	squares := g.board.Squares()
+	for y := MAXY; y >= 0; y-- {
+		empty := 0
+		for x := 0; x <= MAXX; x++ {
+			square := squares[CoordinateToSquareIdentifier(Coordinate{X: uint8(x), Y: uint8(y)})]
+			if square.Empty {
+				empty++
+				continue
+			}
+			if empty > 0 {
+				builder.WriteString(strconv.Itoa(empty))
+				empty = 0
+			}
+			builder.WriteByte(pieceToFEN(square.Piece))
+		}
+		if empty > 0 {
+			builder.WriteString(strconv.Itoa(empty))
+		}
+		if y > 0 {
+			builder.WriteByte('/')
+		}
+	}
+
+	if g.turn.Color == WhiteColor {
+		builder.WriteString(" w ")
+	} else {
+		builder.WriteString(" b ")
+	}
+
+	castling := ""
+	for _, r := range fenCastlingRights {
+		for _, c := range castlings {
+			if c.right == r.right && g.castlingAvailable(c) {
+				castling += string(r.letter)
+			}
+		}
+	}
+	if castling == "" {
+		castling = "-"
+	}
+	builder.WriteString(castling)
+
+	enPassant := "-"
+	if target, ok := g.enPassantTarget(); ok {
+		enPassant = strings.ToLower(SquareIdentifierToString(target))
+	}
+	fmt.Fprintf(&builder, " %s %d %d", enPassant, g.halfmoveClock(), g.fullmoveNumber())
+	return builder.String()
+}
+
+func (g *game) InitialFEN() string {
+	// copied, as the backing array is reused by the movements redone
+	movements := append([]Movement{}, g.movements...)
+	g.Rollback(len(movements))
+	fen := g.FEN()
+	for _, m := range movements {
+		g.redo(m)
+	}
+	return fen
+}
+
+// enPassantTarget returns the square passed by a pawn advancing two squares on the last movement
+func (g *game) enPassantTarget() (SquareIdentifier, bool) {
+	history := g.history()
+	if len(history) == 0 {
+		return 0, false
+	}
+	last := history[len(history)-1]
+	from, to := SquareIdentifierToCoordinate(last.From), SquareIdentifierToCoordinate(last.To)
+	if last.PieceMoved.Identifier() != PawnIdentifier || (int(from.Y)-int(to.Y) != 2 && int(to.Y)-int(from.Y) != 2) {
+		return 0, false
+	}
+	return CoordinateToSquareIdentifier(Coordinate{X: from.X, Y: (from.Y + to.Y) / 2}), true
+}
+
+// fullmoveNumber returns the number of the full move, incremented after black moves
+func (g *game) fullmoveNumber() int {
+	blackStarted := g.turn.Color == BlackColor
+	if len(g.movements)%2 == 1 {
+		blackStarted = !blackStarted
+	}
+	if blackStarted {
+		return g.initialFullmoveNumber + (len(g.movements)+1)/2
+	}
+	return g.initialFullmoveNumber + len(g.movements)/2
+}
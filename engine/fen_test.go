@@ -0,0 +1,106 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFEN(t *testing.T) {
+	assert := assert.New(t)
+
+	// Case: starting position equals a new game
+	game, err := ParseFEN(StartingFEN)
+	assert.NoError(err)
+	newGame, _ := testCaseGameGenerate()
+	assert.Equal(newGame.Board().String(), game.Board().String())
+	assert.Equal(newGame.WhitePieces(), game.WhitePieces())
+	assert.Equal(newGame.BlackPieces(), game.BlackPieces())
+	assert.Equal(WhiteColor, game.Turn().Color)
+	assert.Equal(20, len(game.LegalMoves()))
+
+	// Case: en passant target allows capturing on the first movement
+	game, err = ParseFEN("rnbqkbnr/ppp1pppp/8/8/3pP3/8/PPPP1PPP/RNBQKBNR b KQkq e3 0 3")
+	assert.NoError(err)
+	ok, err := game.Move(testPlayerBlack, D4, E3)
+	assert.True(ok)
+	assert.NoError(err)
+	assert.True(game.Board().Squares()[E4].Empty)
+
+	// Case: castling right missing
+	game, err = ParseFEN("r3k2r/8/8/8/8/8/8/R3K2R w Qkq - 0 1")
+	assert.NoError(err)
+	ok, err = game.Move(testPlayerWhite, E1, G1)
+	assert.False(ok)
+	assert.Error(err)
+	ok, err = game.Move(testPlayerWhite, E1, C1)
+	assert.True(ok)
+	assert.NoError(err)
+
+	// Case: malformed
+	for fen, msg := range map[string]string{
+		"": "invalid FEN: expected 6 fields, got 0",
+		"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP w KQkq - 0 1":               "invalid FEN piece placement \"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP\": expected 8 ranks, got 7",
+		"rnbqkbnr/pppppppp/9/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1":      "invalid FEN piece placement \"rnbqkbnr/pppppppp/9/8/8/8/PPPPPPPP/RNBQKBNR\": unknown piece '9'",
+		"rnbqkbnr/pppppppp/7/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1":      "invalid FEN piece placement \"rnbqkbnr/pppppppp/7/8/8/8/PPPPPPPP/RNBQKBNR\": rank 6 has 7 files",
+		"rnbqqbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1":      "invalid FEN piece placement \"rnbqqbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR\": each player must have exactly one king",
+		"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR x KQkq - 0 1":      "invalid FEN side to move \"x\": must be either \"w\" or \"b\"",
+		"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkx - 0 1":      "invalid FEN castling rights \"KQkx\": unexpected 'x'",
+		"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBN1 w KQkq - 0 1":      "invalid FEN castling rights \"KQkq\": king or rook not in place for 'K'",
+		"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq e3 0 1":     "invalid FEN en passant target \"e3\": wrong rank for the player in turn",
+		"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq e6 0 1":     "invalid FEN en passant target \"e6\": no pawn advanced two squares",
+		"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - -1 1":     "invalid FEN halfmove clock \"-1\": must be a non negative number",
+		"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 0":      "invalid FEN fullmove number \"0\": must be a positive number",
+		"rnbqkbnr/ppppp1pp/8/5p1Q/4P3/8/PPPP1PPP/RNB1KBNR w KQkq - 0 1": "invalid FEN: player not in turn is in check",
+	} {
+		game, err := ParseFEN(fen)
+		assert.Nil(game)
+		assert.EqualError(err, msg)
+	}
+}
+
+func TestFEN(t *testing.T) {
+	runBoardLoaders(t, testFEN)
+}
+
+func testFEN(t *testing.T, loader BoardLoader) {
+	assert := assert.New(t)
+
+	// Case: starting position
+	game, _ := testCaseGameWithBoard(loader)
+	assert.Equal(StartingFEN, game.FEN())
+
+	// Case: counters, en passant target and castling rights after movements
+	game.Move(testPlayerWhite, E2, E4)
+	assert.Equal("rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq e3 0 1", game.FEN())
+	game.Move(testPlayerBlack, G8, F6)
+	assert.Equal("rnbqkb1r/pppppppp/5n2/8/4P3/8/PPPP1PPP/RNBQKBNR w KQkq - 1 2", game.FEN())
+	game.Move(testPlayerWhite, E1, E2)
+	assert.Equal("rnbqkb1r/pppppppp/5n2/8/4P3/8/PPPPKPPP/RNBQ1BNR b kq - 2 2", game.FEN())
+	game.Rollback(1)
+	assert.Equal("rnbqkb1r/pppppppp/5n2/8/4P3/8/PPPP1PPP/RNBQKBNR w KQkq - 1 2", game.FEN())
+
+	// Case: round trip
+	for _, fen := range []string{
+		"r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1",
+		"8/2p5/3p4/KP5r/1R3p1k/8/4P1P1/8 w - - 0 1",
+		"rnbqkbnr/ppp1pppp/8/8/3pP3/8/PPPP1PPP/RNBQKBNR b KQkq e3 0 3",
+		"r3k2r/Pppp1ppp/1b3nbN/nP6/BBP1P3/q4N2/Pp1P2PP/R2Q1RK1 w kq - 0 1",
+	} {
+		game, err := ParseFENWithBoard(fen, loader)
+		assert.NoError(err)
+		assert.Equal(fen, game.FEN())
+	}
+
+	// Case: full move number when black starts
+	game, _ = ParseFENWithBoard("rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq e3 0 1", loader)
+	game.Move(testPlayerBlack, E7, E5)
+	assert.Equal("rnbqkbnr/pppp1ppp/8/4p3/4P3/8/PPPP1PPP/RNBQKBNR w KQkq e6 0 2", game.FEN())
+	game.Move(testPlayerWhite, G1, F3)
+	assert.Equal("rnbqkbnr/pppp1ppp/8/4p3/4P3/5N2/PPPP1PPP/RNBQKB1R b KQkq - 1 2", game.FEN())
+
+	// Case: initial position kept after movements
+	assert.Equal("rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq e3 0 1", game.InitialFEN())
+	assert.Equal("rnbqkbnr/pppp1ppp/8/4p3/4P3/5N2/PPPP1PPP/RNBQKB1R b KQkq - 1 2", game.FEN())
+	assert.Equal(2, len(game.Movements()))
+}
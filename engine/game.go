@@ -2,12 +2,9 @@ package engine
 
 import (
 	"errors"
+	"fmt"
 )
 
-// TODO: Pawn Promotion
-// TODO: check if En passant to delete Pawn
-// TODO: check Castling(short & large)
-
 // Player gammer
 type Player struct {
 	Name  string
@@ -21,6 +18,59 @@ type Movement struct {
 	PieceEaten Piece
 	From       SquareIdentifier
 	To         SquareIdentifier
+	// EnPassant is true when PieceEaten was a pawn taken en passant,
+	// it was placed next to From, not in To
+	EnPassant bool
+	// Castling is true when the king castled, the rook moved from RookFrom to RookTo
+	Castling bool
+	RookFrom SquareIdentifier
+	RookTo   SquareIdentifier
+	// Promotion piece placed in To when a pawn reaches the last rank, nil otherwise
+	Promotion Piece
+	// SAN movement in Standard Algebraic Notation, i.e. "Nf3", "exd5", "O-O", "e8=Q+"
+	SAN string
+}
+
+// castling king and rook squares involved in a castling move
+type castling struct {
+	color    Color
+	kingFrom SquareIdentifier
+	kingTo   SquareIdentifier
+	rookFrom SquareIdentifier
+	rookTo   SquareIdentifier
+	// path squares between king and rook, must be empty
+	path []SquareIdentifier
+	// crossed squares the king stands on or passes through, must not be attacked
+	crossed []SquareIdentifier
+	// right castling right flag
+	right uint8
+}
+
+// castling rights flags
+const (
+	whiteKingSideCastling uint8 = 1 << iota
+	whiteQueenSideCastling
+	blackKingSideCastling
+	blackQueenSideCastling
+
+	allCastlingRights = whiteKingSideCastling | whiteQueenSideCastling | blackKingSideCastling | blackQueenSideCastling
+)
+
+var castlings = []castling{
+	{WhiteColor, E1, G1, H1, F1, []SquareIdentifier{F1, G1}, []SquareIdentifier{E1, F1, G1}, whiteKingSideCastling},
+	{WhiteColor, E1, C1, A1, D1, []SquareIdentifier{D1, C1, B1}, []SquareIdentifier{E1, D1, C1}, whiteQueenSideCastling},
+	{BlackColor, E8, G8, H8, F8, []SquareIdentifier{F8, G8}, []SquareIdentifier{E8, F8, G8}, blackKingSideCastling},
+	{BlackColor, E8, C8, A8, D8, []SquareIdentifier{D8, C8, B8}, []SquareIdentifier{E8, D8, C8}, blackQueenSideCastling},
+}
+
+// findCastling returns castling for king movement from, to
+func findCastling(color Color, from, to SquareIdentifier) (castling, bool) {
+	for _, c := range castlings {
+		if c.color == color && c.kingFrom == from && c.kingTo == to {
+			return c, true
+		}
+	}
+	return castling{}, false
 }
 
 // PiecesList is a map of pieces, each value contains number of pieces left in board
@@ -28,8 +78,13 @@ type PiecesList map[PieceIdentifier]uint8
 
 // Game playable game
 type Game interface {
-	// Move moves a piece in the Board, returns true if moved
-	Move(player Player, from, to SquareIdentifier) (bool, error)
+	// Move moves a piece in the Board, returns true if moved.
+	// promotion is mandatory when a pawn reaches the last rank and must be
+	// either QueenIdentifier, RookIdentifier, BishopIdentifier or KnightIdentifier
+	Move(player Player, from, to SquareIdentifier, promotion ...PieceIdentifier) (bool, error)
+	// Play makes a move for the player in turn without checking if the game finished nor
+	// computing its notation, intended for searches undoing it with Rollback. Returns true if moved
+	Play(move LegalMove) bool
 	// Turn returns player turn
 	Turn() Player
 	// IsCheckBy returns true if Player makes check
@@ -42,10 +97,44 @@ type Game interface {
 	Movements() []Movement
 	// Rollback returns to a previous stage, weight means how many steps back
 	Rollback(weight int)
+	// LegalMoves returns all movements the player in turn can make,
+	// movements leaving its own king in check are excluded
+	LegalMoves() []LegalMove
+	// LegalMovesFrom returns legal movements of the piece in square, empty if
+	// square is empty or the piece is not from the player in turn
+	LegalMovesFrom(square SquareIdentifier) []LegalMove
+
+	// Perft returns the number of positions reached playing every legal move up to depth,
+	// game endings other than checkmate and stalemate are not taken into account
+	Perft(depth int) uint64
+	// Divide returns Perft of depth-1 after each legal move
+	Divide(depth int) map[LegalMove]uint64
+
+	// Status returns game result, with the reason when the game finished
+	Status() Status
+	// Finish ends the game for reasons outside the board, like resignation, timeout or agreement
+	Finish(result Result, reason Reason) error
+
+	// Hash returns the Zobrist hash of the current position, covering pieces placement,
+	// player in turn, castling rights and en passant file
+	Hash() uint64
+	// FEN returns the current position in Forsyth-Edwards Notation
+	FEN() string
+	// InitialFEN returns the position before the first movement in Forsyth-Edwards Notation
+	InitialFEN() string
+	// SAN returns the Standard Algebraic Notation of a legal move in the current position
+	SAN(move LegalMove) string
+	// ParseSAN resolves a movement in Standard Algebraic Notation, i.e. "Nf3", "exd5", "O-O-O", "e8=Q+",
+	// against the legal moves of the player in turn
+	ParseSAN(san string) (LegalMove, error)
+	// PGN returns the game in Portable Game Notation, tags replace the seven tag roster defaults
+	PGN(tags map[string]string) string
 
 	WhitePieces() PiecesList
 	BlackPieces() PiecesList
 
+	// Render returns the board drawn with options, highlighting the last movement squares and the king in check
+	Render(options RenderOptions) string
 	String() string
 }
 
@@ -59,10 +148,30 @@ type game struct {
 	whitePieces PiecesList
 	blackPieces PiecesList
 	movements   []Movement
+	finished    *Status
+
+	// hash Zobrist hash of the current position
+	hash uint64
+	// hashes Zobrist hashes of the positions before each movement
+	hashes []uint64
+
+	// castlingRights rights available before the first movement
+	castlingRights uint8
+	// enPassant target square before the first movement, 0 if none
+	enPassant SquareIdentifier
+	// initialHalfmoveClock halfmoves since last pawn movement or capture before the first movement
+	initialHalfmoveClock int
+	// initialFullmoveNumber full move number before the first movement
+	initialFullmoveNumber int
 }
 
 // NewGame creates new Game
 func NewGame(name string, black, white Player) (Game, error) {
+	return NewGameWithBoard(name, black, white, LoadBoard)
+}
+
+// NewGameWithBoard creates new Game played on the Board created by loader, i.e. LoadBitboard for searches
+func NewGameWithBoard(name string, black, white Player, loader BoardLoader) (Game, error) {
 	if white.Color != WhiteColor || black.Color != BlackColor {
 		return nil, errors.New("must define black and white players")
 	}
@@ -83,15 +192,20 @@ func NewGame(name string, black, white Player) (Game, error) {
 		KingIdentifier:   1,
 		PawnIdentifier:   8,
 	}
-	return &game{
+	g := &game{
 		name:        name,
-		board:       NewBoard(&white, &black),
+		board:       loader(&white, &black, PristineSquares()),
 		turn:        white,
 		white:       white,
 		black:       black,
 		blackPieces: blackPieces,
 		whitePieces: whitePieces,
-	}, nil
+
+		castlingRights:        allCastlingRights,
+		initialFullmoveNumber: 1,
+	}
+	g.initHash()
+	return g, nil
 }
 
 // LoadGame loads in game being played
@@ -104,7 +218,7 @@ func LoadGame(
 	blackPieces PiecesList,
 	movements []Movement,
 ) (Game, error) {
-	return &game{
+	g := &game{
 		name:        name,
 		board:       board,
 		turn:        turn,
@@ -113,18 +227,43 @@ func LoadGame(
 		whitePieces: whitePieces,
 		blackPieces: blackPieces,
 		movements:   movements,
-	}, nil
+
+		castlingRights:        allCastlingRights,
+		initialFullmoveNumber: 1,
+	}
+	g.initHash()
+	return g, nil
 }
 
-func (g *game) Move(player Player, from, to SquareIdentifier) (bool, error) {
-	if g.IsCheckmateBy(g.white) {
+func (g *game) Move(player Player, from, to SquareIdentifier, promotion ...PieceIdentifier) (bool, error) {
+	switch status := g.Status(); {
+	case status.Result == WhiteWinsResult && status.Reason == CheckmateReason:
 		return false, errors.New("checkmate, winner is white")
-	}
-	if g.IsCheckmateBy(g.black) {
+	case status.Result == BlackWinsResult && status.Reason == CheckmateReason:
 		return false, errors.New("checkmate, winner is black")
+	case status.Result != OngoingResult:
+		return false, fmt.Errorf("game finished, %s", status)
+	}
+	if ok, err := g.move(player, from, to, promotion...); !ok {
+		return ok, err
 	}
-	squareFrom := g.board.Squares()[from]
-	squareTo := g.board.Squares()[to]
+	// SAN depends on the position before the movement and on the one after
+	m := g.movements[len(g.movements)-1]
+	g.Rollback(1)
+	m.SAN = g.san(m.LegalMove())
+	g.redo(m)
+	return true, nil
+}
+
+func (g *game) Play(m LegalMove) bool {
+	ok, _ := g.move(g.turn, m.From, m.To, m.Promotion)
+	return ok
+}
+
+// move moves a piece without checking if the game already finished
+func (g *game) move(player Player, from, to SquareIdentifier, promotion ...PieceIdentifier) (bool, error) {
+	squareFrom := g.square(from)
+	squareTo := g.square(to)
 	if squareFrom.Empty {
 		return false, errors.New("square from is empty")
 	}
@@ -134,30 +273,61 @@ func (g *game) Move(player Player, from, to SquareIdentifier) (bool, error) {
 		return false, errors.New("piece is not for the player color")
 	}
 
-	canMove := squareFrom.Piece.CanMove(g.board, g.Movements(), squareFrom, squareTo)
-	if !canMove {
+	if pieceToMove.Identifier() == KingIdentifier {
+		if c, ok := findCastling(pieceToMove.Color(), from, to); ok {
+			return g.castle(player, c)
+		}
+	}
+
+	if !g.canMoveFrom(squareFrom)(squareTo) {
 		return false, errors.New("not valid piece movement")
 	}
 
+	var promoted Piece
+	if pieceToMove.Identifier() == PawnIdentifier && (squareTo.Coordinates.Y == 0 || squareTo.Coordinates.Y == MAXY) {
+		if len(promotion) == 0 || promotion[0] == 0 {
+			return false, errors.New("pawn promotion piece is missing")
+		}
+		switch promotion[0] {
+		case QueenIdentifier, RookIdentifier, BishopIdentifier, KnightIdentifier:
+			promoted = PieceFromPieceIdentifier(promotion[0], pieceToMove.Color())
+		default:
+			return false, errors.New("pawn can only be promoted to queen, rook, bishop or knight")
+		}
+	} else if len(promotion) > 0 && promotion[0] != 0 {
+		return false, errors.New("promotion is only allowed when a pawn reaches the last rank")
+	}
+
 	var pieceEaten Piece
+	enPassant := false
 	if !squareTo.Empty {
 		pieceEaten = g.board.EatPiece(to)
 		g.removePiecePlayer(pieceEaten)
+	} else if pieceToMove.Identifier() == PawnIdentifier && squareFrom.Coordinates.X != squareTo.Coordinates.X {
+		enPassant = true
+		pieceEaten = g.board.EatPiece(enPassantSquare(from, to))
+		g.removePiecePlayer(pieceEaten)
 	}
 
-	squareTo.Piece = pieceToMove
-	squareTo.Empty = false
-	squareFrom.Piece = nil
-	squareFrom.Empty = true
-	g.board.Squares()[to] = squareTo
-	g.board.Squares()[from] = squareFrom
+	g.board.EatPiece(from)
+	if promoted != nil {
+		g.board.FillSquare(to, promoted)
+		g.removePiecePlayer(pieceToMove)
+		g.addPiecePlayer(promoted)
+	} else {
+		g.board.FillSquare(to, pieceToMove)
+	}
 	g.movements = append(g.movements, Movement{
 		Player:     player,
 		PieceMoved: pieceToMove,
 		PieceEaten: pieceEaten,
 		From:       from,
 		To:         to,
+		EnPassant:  enPassant,
+		Promotion:  promoted,
 	})
+	g.hashes = append(g.hashes, g.hash)
+	g.hash ^= g.zobristMovement(len(g.movements) - 1)
 	g.changeTurn()
 	if g.IsCheckBy(g.Turn()) {
 		g.Rollback(1)
@@ -166,6 +336,88 @@ func (g *game) Move(player Player, from, to SquareIdentifier) (bool, error) {
 	return true, nil
 }
 
+// castle moves king and rook when castling rules are satisfied:
+// neither king nor rook moved before, path between them is empty and
+// king is not in check, nor passes through or lands on an attacked square
+func (g *game) castle(player Player, c castling) (bool, error) {
+	rookSquare := g.square(c.rookFrom)
+	if rookSquare.Empty || rookSquare.Piece.Identifier() != RookIdentifier || rookSquare.Piece.Color() != c.color {
+		return false, errors.New("castling not allowed, rook is missing")
+	}
+	if !g.castlingAvailable(c) {
+		return false, errors.New("castling not allowed, king or rook already moved")
+	}
+	for _, loc := range c.path {
+		if !g.square(loc).Empty {
+			return false, errors.New("castling not allowed, path is not empty")
+		}
+	}
+	for _, loc := range c.crossed {
+		if kingEatableInSquare(c.color, g.board, g.history(), g.square(loc)) {
+			return false, errors.New("castling not allowed, king is attacked")
+		}
+	}
+
+	king := g.board.EatPiece(c.kingFrom)
+	rook := g.board.EatPiece(c.rookFrom)
+	g.board.FillSquare(c.kingTo, king)
+	g.board.FillSquare(c.rookTo, rook)
+	g.movements = append(g.movements, Movement{
+		Player:     player,
+		PieceMoved: king,
+		From:       c.kingFrom,
+		To:         c.kingTo,
+		Castling:   true,
+		RookFrom:   c.rookFrom,
+		RookTo:     c.rookTo,
+	})
+	g.hashes = append(g.hashes, g.hash)
+	g.hash ^= g.zobristMovement(len(g.movements) - 1)
+	g.changeTurn()
+	return true, nil
+}
+
+// castlingAvailable returns true if the castling right was available on the first movement
+// and neither king nor rook involved in castling were moved since
+func (g *game) castlingAvailable(c castling) bool {
+	return g.castlingAvailableAfter(c, len(g.movements))
+}
+
+// castlingAvailableAfter returns true if castling was available after the first n movements
+func (g *game) castlingAvailableAfter(c castling, n int) bool {
+	return g.castlingRights&c.right != 0 && !hasMoved(g.movements[:n], c.kingFrom) && !hasMoved(g.movements[:n], c.rookFrom)
+}
+
+// history returns movements passed to pieces CanMove, when the game started from a position
+// with an en passant target and no movement was made yet, the opponent pawn double step is included
+func (g *game) history() []Movement {
+	if len(g.movements) > 0 || g.enPassant == 0 {
+		return g.movements
+	}
+	target := SquareIdentifierToCoordinate(g.enPassant)
+	// a white pawn passes through the 3rd rank, a black pawn through the 6th one
+	color, player, fromY, toY := WhiteColor, g.white, uint8(1), uint8(3)
+	if target.Y == 5 {
+		color, player, fromY, toY = BlackColor, g.black, 6, 4
+	}
+	return []Movement{{
+		Player:     player,
+		PieceMoved: NewPawn(color),
+		From:       CoordinateToSquareIdentifier(Coordinate{X: target.X, Y: fromY}),
+		To:         CoordinateToSquareIdentifier(Coordinate{X: target.X, Y: toY}),
+	}}
+}
+
+// hasMoved returns true if the piece initially placed in loc was moved or eaten in movements
+func hasMoved(movements []Movement, loc SquareIdentifier) bool {
+	for _, m := range movements {
+		if m.From == loc || m.To == loc || m.Castling && (m.RookFrom == loc || m.RookTo == loc) {
+			return true
+		}
+	}
+	return false
+}
+
 func (g *game) Turn() Player {
 	return g.turn
 }
@@ -178,7 +430,7 @@ func (g *game) IsCheckBy(player Player) bool {
 	} else {
 		kingSquare = getKingSquare(g.Board(), WhiteColor)
 	}
-	if kingEatableInSquare(kingSquare.Piece.Color(), g.Board(), g.Movements(), kingSquare) {
+	if kingEatableInSquare(kingSquare.Piece.Color(), g.Board(), g.history(), kingSquare) {
 		return true
 	}
 	return false
@@ -188,27 +440,10 @@ func (g *game) IsCheckmateBy(player Player) bool {
 	if !g.IsCheckBy(player) {
 		return false
 	}
-	var kingSquare Square
-	playerColor := player.Color
-	if playerColor == WhiteColor {
-		kingSquare = getKingSquare(g.Board(), BlackColor)
-	} else {
-		kingSquare = getKingSquare(g.Board(), WhiteColor)
+	if player.Color == WhiteColor {
+		return !g.hasLegalMove(g.black)
 	}
-	// iterate all possible movements and checks if king can move to at least one direction
-	for x := kingSquare.Coordinates.X - 1; x <= kingSquare.Coordinates.X+1; x++ {
-		for y := kingSquare.Coordinates.Y - 1; y <= kingSquare.Coordinates.Y+1; y++ {
-			if (x > MAXX || y > MAXY) || x == kingSquare.Coordinates.X && y == kingSquare.Coordinates.Y {
-				continue
-			}
-			if kingSquare.Piece.CanMove(g.Board(), g.Movements(), kingSquare, g.Board().Squares()[CoordinateToSquareIdentifier(Coordinate{x, y})]) {
-				return false
-			}
-		}
-	}
-	// TODO: review if any piece player can block the check
-	// we probably want to have all pieces making check
-	return true
+	return !g.hasLegalMove(g.white)
 }
 
 func (g *game) Board() Board {
@@ -222,31 +457,40 @@ func (g *game) Movements() []Movement {
 func (g *game) Rollback(w int) {
 	for i := 1; i <= w; i++ {
 		lastMovement := g.movements[len(g.movements)-1]
-		squareFrom := Square{
-			Empty:            false,
-			Piece:            lastMovement.PieceMoved,
-			Coordinates:      SquareIdentifierToCoordinate(lastMovement.From),
-			SquareIdentifier: lastMovement.From,
+		g.hash ^= g.zobristMovement(len(g.movements) - 1)
+		g.hashes = g.hashes[:len(g.hashes)-1]
+		if lastMovement.Castling {
+			rook := g.board.EatPiece(lastMovement.RookTo)
+			g.board.FillSquare(lastMovement.RookFrom, rook)
 		}
 
-		squareTo := Square{
-			Piece:            lastMovement.PieceEaten,
-			Coordinates:      SquareIdentifierToCoordinate(lastMovement.To),
-			SquareIdentifier: lastMovement.To,
-		}
-
-		if squareTo.Piece == nil {
-			squareTo.Empty = true
+		if lastMovement.EnPassant {
+			g.board.FillSquare(lastMovement.To, nil)
+			g.board.FillSquare(enPassantSquare(lastMovement.From, lastMovement.To), lastMovement.PieceEaten)
 		} else {
-			g.addPiecePlayer(squareTo.Piece)
+			g.board.FillSquare(lastMovement.To, lastMovement.PieceEaten)
+		}
+		if lastMovement.PieceEaten != nil {
+			g.addPiecePlayer(lastMovement.PieceEaten)
+		}
+		if lastMovement.Promotion != nil {
+			g.removePiecePlayer(lastMovement.Promotion)
+			g.addPiecePlayer(lastMovement.PieceMoved)
 		}
-		g.board.Squares()[lastMovement.To] = squareTo
-		g.board.Squares()[lastMovement.From] = squareFrom
+		g.board.FillSquare(lastMovement.From, lastMovement.PieceMoved)
 		g.movements = g.movements[:len(g.movements)-1]
 		g.changeTurn()
 	}
 }
 
+// redo plays again a movement undone by Rollback
+func (g *game) redo(m Movement) {
+	lm := m.LegalMove()
+	if ok, _ := g.move(m.Player, lm.From, lm.To, lm.Promotion); ok {
+		g.movements[len(g.movements)-1].SAN = m.SAN
+	}
+}
+
 func (g *game) WhitePieces() PiecesList {
 	return g.whitePieces
 }
@@ -255,6 +499,17 @@ func (g *game) BlackPieces() PiecesList {
 	return g.blackPieces
 }
 
+func (g *game) Render(options RenderOptions) string {
+	if len(g.movements) > 0 {
+		last := g.movements[len(g.movements)-1]
+		options.Highlight = append(options.Highlight, last.From, last.To)
+	}
+	if g.IsCheckBy(g.oponentTurn()) {
+		options.Check = getKingSquare(g.board, g.turn.Color).SquareIdentifier
+	}
+	return g.board.Render(options)
+}
+
 // String returns ASCII representation of the game
 func (g *game) String() string {
 	return g.board.String()
@@ -288,7 +543,21 @@ func (g *game) addPiecePlayer(p Piece) {
 	}
 }
 
+// square returns the square loc of the board, without copying the bitboard squares
+func (g *game) square(loc SquareIdentifier) Square {
+	if fb, ok := g.board.(fastBoard); ok {
+		return fb.square(loc)
+	}
+	return g.board.Squares()[loc]
+}
+
 func getKingSquare(board Board, color Color) Square {
+	if fb, ok := board.(fastBoard); ok {
+		if loc, ok := fb.kingSquare(color); ok {
+			return fb.square(loc)
+		}
+		return Square{}
+	}
 	var kingSquare Square
 	for _, square := range board.Squares() {
 		if !square.Empty && square.Piece.Identifier() == KingIdentifier && square.Piece.Color() == color {
@@ -299,7 +568,7 @@ func getKingSquare(board Board, color Color) Square {
 	return kingSquare
 }
 
-func (g *game) oponentTurn() Player { // TODO: still needs test
+func (g *game) oponentTurn() Player {
 	if g.Turn() == g.white {
 		return g.black
 	}
@@ -12,10 +12,15 @@ var testPlayerBlack = Player{Name: "Joel", Color: BlackColor}
 var testPlayerWhite = Player{Name: "Luis", Color: WhiteColor}
 
 var testCaseGameGenerate = func() (Game, error) {
-	return NewGame(
+	return testCaseGameWithBoard(LoadBoard)
+}
+
+func testCaseGameWithBoard(loader BoardLoader) (Game, error) {
+	return NewGameWithBoard(
 		"asaditosGame",
 		testPlayerBlack,
 		testPlayerWhite,
+		loader,
 	)
 }
 
@@ -59,10 +64,14 @@ func TestTurn(t *testing.T) {
 }
 
 func TestMove(t *testing.T) {
+	runBoardLoaders(t, testMove)
+}
+
+func testMove(t *testing.T, loader BoardLoader) {
 	var testCaseGame Game
 	assert := assert.New(t)
 
-	testCaseGame, _ = testCaseGameGenerate()
+	testCaseGame, _ = testCaseGameWithBoard(loader)
 	ok, e := testCaseGame.Move(testPlayerWhite, A2, A3)
 	assert.Equal(true, ok)
 	assert.Empty(e)
@@ -88,42 +97,186 @@ func TestMove(t *testing.T) {
 	assert.NotNil(e)
 
 	// Testcase when movement eats a piece, white pawn eats black pawn
-	testCaseGame, _ = testCaseGameGenerate()
+	testCaseGame, _ = testCaseGameWithBoard(loader)
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
-	c3whitePawnSquare := Square{
-		Empty:            false,
-		Piece:            NewPawn(WhiteColor),
-		SquareIdentifier: C3,
-		Coordinates: Coordinate{
-			X: 3,
-			Y: 2,
-		},
-	}
-	d4blackPawnSquare := Square{
-		Empty:            false,
-		Piece:            NewPawn(BlackColor),
-		SquareIdentifier: D4,
-		Coordinates: Coordinate{
-			X: 4,
-			Y: 3,
-		},
-	}
-
-	testCaseGame.Board().Squares()[C3] = c3whitePawnSquare
-	testCaseGame.Board().Squares()[D4] = d4blackPawnSquare
+	testCaseGame.Board().FillSquare(C3, NewPawn(WhiteColor))
+	testCaseGame.Board().FillSquare(D4, NewPawn(BlackColor))
 	ok, err := testCaseGame.Move(testPlayerWhite, C3, D4)
 	assert.True(ok)
 	assert.Empty(err)
 }
 
+func TestMoveCastling(t *testing.T) {
+	runBoardLoaders(t, testMoveCastling)
+}
+
+func testMoveCastling(t *testing.T, loader BoardLoader) {
+	assert := assert.New(t)
+
+	// Case: king side castling, rollback restores king and rook
+	game, _ := testCaseGameWithBoard(loader)
+	game.Board().EatPiece(F1)
+	game.Board().EatPiece(G1)
+	ok, err := game.Move(testPlayerWhite, E1, G1)
+	assert.True(ok)
+	assert.Nil(err)
+	assert.Equal(KingIdentifier, game.Board().Squares()[G1].Piece.Identifier())
+	assert.Equal(RookIdentifier, game.Board().Squares()[F1].Piece.Identifier())
+	assert.True(game.Board().Squares()[E1].Empty)
+	assert.True(game.Board().Squares()[H1].Empty)
+	assert.True(game.Movements()[0].Castling)
+
+	game.Rollback(1)
+	assert.Equal(KingIdentifier, game.Board().Squares()[E1].Piece.Identifier())
+	assert.Equal(RookIdentifier, game.Board().Squares()[H1].Piece.Identifier())
+	assert.True(game.Board().Squares()[F1].Empty)
+	assert.True(game.Board().Squares()[G1].Empty)
+	assert.Equal(testPlayerWhite, game.Turn())
+
+	// Case: queen side castling for black
+	game, _ = testCaseGameWithBoard(loader)
+	game.Board().EatPiece(B8)
+	game.Board().EatPiece(C8)
+	game.Board().EatPiece(D8)
+	game.Move(testPlayerWhite, A2, A3)
+	ok, err = game.Move(testPlayerBlack, E8, C8)
+	assert.True(ok)
+	assert.Nil(err)
+	assert.Equal(KingIdentifier, game.Board().Squares()[C8].Piece.Identifier())
+	assert.Equal(RookIdentifier, game.Board().Squares()[D8].Piece.Identifier())
+
+	// Case: path not empty
+	game, _ = testCaseGameWithBoard(loader)
+	game.Board().EatPiece(G1)
+	ok, err = game.Move(testPlayerWhite, E1, G1)
+	assert.False(ok)
+	assert.Error(err)
+
+	// Case: rook already moved
+	game, _ = testCaseGameWithBoard(loader)
+	game.Board().EatPiece(F1)
+	game.Board().EatPiece(G1)
+	game.Move(testPlayerWhite, H1, G1)
+	game.Move(testPlayerBlack, A7, A6)
+	game.Move(testPlayerWhite, G1, H1)
+	game.Move(testPlayerBlack, A6, A5)
+	ok, err = game.Move(testPlayerWhite, E1, G1)
+	assert.False(ok)
+	assert.Error(err)
+
+	// Case: king passes through an attacked square
+	game, _ = testCaseGameWithBoard(loader)
+	game.Board().EatPiece(F1)
+	game.Board().EatPiece(G1)
+	game.Board().EatPiece(F2)
+	game.Board().FillSquare(F3, NewRook(BlackColor))
+	ok, err = game.Move(testPlayerWhite, E1, G1)
+	assert.False(ok)
+	assert.Error(err)
+
+	// Case: king in check
+	game, _ = testCaseGameWithBoard(loader)
+	game.Board().EatPiece(F1)
+	game.Board().EatPiece(G1)
+	game.Board().EatPiece(E2)
+	game.Board().FillSquare(E3, NewRook(BlackColor))
+	ok, err = game.Move(testPlayerWhite, E1, G1)
+	assert.False(ok)
+	assert.Error(err)
+}
+
+func TestMoveEnPassant(t *testing.T) {
+	runBoardLoaders(t, testMoveEnPassant)
+}
+
+func testMoveEnPassant(t *testing.T, loader BoardLoader) {
+	assert := assert.New(t)
+
+	game, _ := testCaseGameWithBoard(loader)
+	game.Move(testPlayerWhite, E2, E4)
+	game.Move(testPlayerBlack, A7, A6)
+	game.Move(testPlayerWhite, E4, E5)
+	game.Move(testPlayerBlack, D7, D5)
+	ok, err := game.Move(testPlayerWhite, E5, D6)
+	assert.True(ok)
+	assert.Nil(err)
+	assert.True(game.Board().Squares()[D5].Empty)
+	assert.Equal(PawnIdentifier, game.Board().Squares()[D6].Piece.Identifier())
+	assert.Equal(uint8(7), game.BlackPieces()[PawnIdentifier])
+	last := game.Movements()[len(game.Movements())-1]
+	assert.True(last.EnPassant)
+	assert.Equal(NewPawn(BlackColor), last.PieceEaten)
+
+	game.Rollback(1)
+	assert.True(game.Board().Squares()[D6].Empty)
+	assert.Equal(NewPawn(BlackColor), game.Board().Squares()[D5].Piece)
+	assert.Equal(NewPawn(WhiteColor), game.Board().Squares()[E5].Piece)
+	assert.Equal(uint8(8), game.BlackPieces()[PawnIdentifier])
+
+	// Case: en passant is only allowed right after the double step
+	game.Move(testPlayerWhite, H2, H3)
+	game.Move(testPlayerBlack, A6, A5)
+	ok, err = game.Move(testPlayerWhite, E5, D6)
+	assert.False(ok)
+	assert.Error(err)
+}
+
+func TestMovePromotion(t *testing.T) {
+	runBoardLoaders(t, testMovePromotion)
+}
+
+func testMovePromotion(t *testing.T, loader BoardLoader) {
+	assert := assert.New(t)
+
+	game, _ := testCaseGameWithBoard(loader)
+	game.Board().EatPiece(B8)
+	game.Board().FillSquare(B7, NewPawn(WhiteColor))
+	game.Board().EatPiece(B2)
+
+	// Case: promotion piece is mandatory
+	ok, err := game.Move(testPlayerWhite, B7, B8)
+	assert.False(ok)
+	assert.Error(err)
+
+	// Case: cannot promote to king
+	ok, err = game.Move(testPlayerWhite, B7, B8, KingIdentifier)
+	assert.False(ok)
+	assert.Error(err)
+
+	// Case: promotion only on last rank
+	ok, err = game.Move(testPlayerWhite, A2, A3, QueenIdentifier)
+	assert.False(ok)
+	assert.Error(err)
+
+	ok, err = game.Move(testPlayerWhite, B7, A8, KnightIdentifier)
+	assert.True(ok)
+	assert.Nil(err)
+	assert.Equal(NewKnight(WhiteColor), game.Board().Squares()[A8].Piece)
+	assert.Equal(NewKnight(WhiteColor), game.Movements()[0].Promotion)
+	assert.Equal(uint8(7), game.WhitePieces()[PawnIdentifier])
+	assert.Equal(uint8(3), game.WhitePieces()[KnightIdentifier])
+	assert.Equal(uint8(1), game.BlackPieces()[RookIdentifier])
+
+	game.Rollback(1)
+	assert.Equal(NewPawn(WhiteColor), game.Board().Squares()[B7].Piece)
+	assert.Equal(NewRook(BlackColor), game.Board().Squares()[A8].Piece)
+	assert.Equal(uint8(8), game.WhitePieces()[PawnIdentifier])
+	assert.Equal(uint8(2), game.WhitePieces()[KnightIdentifier])
+	assert.Equal(uint8(2), game.BlackPieces()[RookIdentifier])
+}
+
 func TestIsCheckmateBy(t *testing.T) {
+	runBoardLoaders(t, testIsCheckmateBy)
+}
+
+func testIsCheckmateBy(t *testing.T, loader BoardLoader) {
 	assert := assert.New(t)
 
 	// Case: checkmate, Fool's mate
 	Joel := Player{"Joel", WhiteColor}
 	Luis := Player{"Luis", BlackColor}
-	game, e := NewGame("Fool's mate", Luis, Joel)
+	game, e := NewGameWithBoard("Fool's mate", Luis, Joel, loader)
 	check(e)
 
 	game.Move(Joel, E2, E4)
@@ -140,14 +293,38 @@ func TestIsCheckmateBy(t *testing.T) {
 	ok, err := game.Move(Luis, C7, C6)
 	assert.False(ok)
 	assert.Error(err)
+
+	// Case: check can be blocked
+	game, e = NewGameWithBoard("blocked check", Luis, Joel, loader)
+	check(e)
+	game.Move(Joel, E2, E4)
+	game.Move(Luis, F7, F5)
+	game.Move(Joel, D1, H5)
+	assert.True(game.IsCheckBy(Joel))
+	assert.False(game.IsCheckmateBy(Joel))
+	ok, err = game.Move(Luis, G7, G6)
+	assert.True(ok)
+	assert.Nil(err)
+
+	// Case: checking piece can be eaten
+	game.Move(Joel, H5, G6)
+	assert.True(game.IsCheckBy(Joel))
+	assert.False(game.IsCheckmateBy(Joel))
+	ok, err = game.Move(Luis, H7, G6)
+	assert.True(ok)
+	assert.Nil(err)
 }
 
 func TestIsCheck(t *testing.T) {
+	runBoardLoaders(t, testIsCheck)
+}
+
+func testIsCheck(t *testing.T, loader BoardLoader) {
 	assert := assert.New(t)
 
 	Joel := Player{"Joel", WhiteColor}
 	Luis := Player{"Luis", BlackColor}
-	game, e := NewGame("rapid check", Luis, Joel)
+	game, e := NewGameWithBoard("rapid check", Luis, Joel, loader)
 	assert.Nil(e)
 
 	game.Move(Joel, D2, D4)
@@ -165,10 +342,14 @@ func TestIsCheck(t *testing.T) {
 }
 
 func TestRollback(t *testing.T) {
+	runBoardLoaders(t, testRollback)
+}
+
+func testRollback(t *testing.T, loader BoardLoader) {
 	assert := assert.New(t)
 	Joel := Player{"Joel", WhiteColor}
 	Luis := Player{"Luis", BlackColor}
-	game, e := NewGame("rapid check", Luis, Joel)
+	game, e := NewGameWithBoard("rapid check", Luis, Joel, loader)
 	assert.Nil(e)
 
 	game.Move(Joel, D2, D4)
@@ -0,0 +1,168 @@
+package engine
+
+import "strings"
+
+// LegalMove a movement allowed in the current game position
+type LegalMove struct {
+	From SquareIdentifier
+	To   SquareIdentifier
+	// Promotion piece when a pawn reaches the last rank, 0 otherwise
+	Promotion PieceIdentifier
+}
+
+// String returns the move in long algebraic notation, i.e. "e2e4", "e7e8q"
+func (m LegalMove) String() string {
+	move := strings.ToLower(SquareIdentifierToString(m.From) + SquareIdentifierToString(m.To))
+	if m.Promotion != 0 {
+		move += string(fenPieces[m.Promotion])
+	}
+	return move
+}
+
+// LegalMove returns the LegalMove equivalent to the Movement made
+func (m Movement) LegalMove() LegalMove {
+	lm := LegalMove{From: m.From, To: m.To}
+	if m.Promotion != nil {
+		lm.Promotion = m.Promotion.Identifier()
+	}
+	return lm
+}
+
+var promotionPieces = []PieceIdentifier{QueenIdentifier, RookIdentifier, BishopIdentifier, KnightIdentifier}
+
+var knightSteps = []Coordinate{{1, 2}, {2, 1}, {2, 255}, {1, 254}, {255, 254}, {254, 255}, {254, 1}, {255, 2}}
+var kingSteps = []Coordinate{{0, 1}, {1, 1}, {1, 0}, {1, 255}, {0, 255}, {255, 255}, {255, 0}, {255, 1}}
+var rookDirections = []Coordinate{{0, 1}, {1, 0}, {0, 255}, {255, 0}}
+var bishopDirections = []Coordinate{{1, 1}, {1, 255}, {255, 255}, {255, 1}}
+
+func (g *game) LegalMoves() []LegalMove {
+	return g.legalMoves(g.turn, g.playerSquares(g.turn), false)
+}
+
+func (g *game) LegalMovesFrom(loc SquareIdentifier) []LegalMove {
+	square := g.square(loc)
+	if square.Empty || square.Piece.Color() != g.turn.Color {
+		return []LegalMove{}
+	}
+	return g.legalMoves(g.turn, []Square{square}, false)
+}
+
+// hasLegalMove returns true if player has at least one movement which does not leave its king in check
+func (g *game) hasLegalMove(player Player) bool {
+	return len(g.legalMoves(player, g.playerSquares(player), true)) > 0
+}
+
+// playerSquares returns squares filled with player pieces
+func (g *game) playerSquares(player Player) []Square {
+	var squares []Square
+	for loc := A1; loc <= H8; loc++ {
+		square := g.square(loc)
+		if !square.Empty && square.Piece.Color() == player.Color {
+			squares = append(squares, square)
+		}
+	}
+	return squares
+}
+
+// legalMoves returns player legal movements of pieces placed in fromSquares, stops on the first one found if first.
+// Each candidate movement is validated by canMoveFrom and then played and rolled back,
+// as the self-check test is done by move
+func (g *game) legalMoves(player Player, fromSquares []Square, first bool) []LegalMove {
+	turn := g.turn
+	g.turn = player
+	defer func() { g.turn = turn }()
+
+	moves := []LegalMove{}
+	for _, squareFrom := range fromSquares {
+		canMove := g.canMoveFrom(squareFrom)
+		for _, to := range candidateSquares(squareFrom) {
+			squareTo := g.square(to)
+			if _, castling := findCastling(squareFrom.Piece.Color(), squareFrom.SquareIdentifier, to); !castling && !canMove(squareTo) {
+				continue
+			}
+			promotions := []PieceIdentifier{0}
+			if squareFrom.Piece.Identifier() == PawnIdentifier && (squareTo.Coordinates.Y == 0 || squareTo.Coordinates.Y == MAXY) {
+				promotions = promotionPieces
+			}
+			for _, promotion := range promotions {
+				if ok, _ := g.move(player, squareFrom.SquareIdentifier, to, promotion); !ok {
+					break
+				}
+				g.Rollback(1)
+				moves = append(moves, LegalMove{From: squareFrom.SquareIdentifier, To: to, Promotion: promotion})
+				if first {
+					return moves
+				}
+			}
+		}
+	}
+	return moves
+}
+
+// canMoveFrom returns whether the piece in from moves to a square, castling excluded and regardless of
+// its king safety. Bitboards answer it for every square at once, pieces CanMove otherwise
+func (g *game) canMoveFrom(from Square) func(to Square) bool {
+	if fb, ok := g.board.(fastBoard); ok {
+		enPassant, _ := g.enPassantTarget()
+		moves := fb.moves(from.SquareIdentifier, enPassant)
+		return func(to Square) bool {
+			return moves&squareBit(to.SquareIdentifier) != 0
+		}
+	}
+	history := g.history()
+	return func(to Square) bool {
+		return from.Piece.CanMove(g.board, history, from, to)
+	}
+}
+
+// candidateSquares returns squares the piece in square could reach on an empty board
+func candidateSquares(square Square) []SquareIdentifier {
+	var candidates []SquareIdentifier
+	from := square.Coordinates
+	switch square.Piece.Identifier() {
+	case PawnIdentifier:
+		dy := uint8(1)
+		if square.Piece.Color() == BlackColor {
+			dy = 255
+		}
+		candidates = appendSteps(candidates, from, []Coordinate{{0, dy}, {0, dy * 2}, {1, dy}, {255, dy}})
+	case KnightIdentifier:
+		candidates = appendSteps(candidates, from, knightSteps)
+	case KingIdentifier:
+		candidates = appendSteps(candidates, from, kingSteps)
+		for _, c := range castlings {
+			if c.color == square.Piece.Color() && c.kingFrom == square.SquareIdentifier {
+				candidates = append(candidates, c.kingTo)
+			}
+		}
+	case RookIdentifier:
+		candidates = appendRays(candidates, from, rookDirections)
+	case BishopIdentifier:
+		candidates = appendRays(candidates, from, bishopDirections)
+	case QueenIdentifier:
+		candidates = appendRays(candidates, from, rookDirections)
+		candidates = appendRays(candidates, from, bishopDirections)
+	}
+	return candidates
+}
+
+// appendSteps appends from+step squares within the board, negative steps overflow uint8
+func appendSteps(candidates []SquareIdentifier, from Coordinate, steps []Coordinate) []SquareIdentifier {
+	for _, step := range steps {
+		to := Coordinate{X: from.X + step.X, Y: from.Y + step.Y}
+		if to.X <= MAXX && to.Y <= MAXY {
+			candidates = append(candidates, CoordinateToSquareIdentifier(to))
+		}
+	}
+	return candidates
+}
+
+// appendRays appends every square from the from square to the board limits on each direction
+func appendRays(candidates []SquareIdentifier, from Coordinate, directions []Coordinate) []SquareIdentifier {
+	for _, direction := range directions {
+		for to := (Coordinate{X: from.X + direction.X, Y: from.Y + direction.Y}); to.X <= MAXX && to.Y <= MAXY; to = (Coordinate{X: to.X + direction.X, Y: to.Y + direction.Y}) {
+			candidates = append(candidates, CoordinateToSquareIdentifier(to))
+		}
+	}
+	return candidates
+}
@@ -0,0 +1,84 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLegalMoves(t *testing.T) {
+	runBoardLoaders(t, testLegalMoves)
+}
+
+func testLegalMoves(t *testing.T, loader BoardLoader) {
+	assert := assert.New(t)
+
+	// Case: initial position
+	game, _ := testCaseGameWithBoard(loader)
+	assert.Len(game.LegalMoves(), 20)
+	assert.ElementsMatch(
+		[]LegalMove{{From: B1, To: A3}, {From: B1, To: C3}},
+		game.LegalMovesFrom(B1),
+	)
+	assert.Empty(game.LegalMovesFrom(E4))
+	assert.Empty(game.LegalMovesFrom(E7))
+
+	// Case: pawn cannot jump over a piece on first movement
+	game.Board().FillSquare(E3, NewKnight(BlackColor))
+	assert.ElementsMatch([]LegalMove{}, game.LegalMovesFrom(E2))
+	assert.ElementsMatch(
+		[]LegalMove{{From: D2, To: D3}, {From: D2, To: D4}, {From: D2, To: E3}},
+		game.LegalMovesFrom(D2),
+	)
+
+	// Case: castling and promotions
+	game, _ = testCaseGameWithBoard(loader)
+	game.Board().EatPiece(F1)
+	game.Board().EatPiece(G1)
+	game.Board().EatPiece(B8)
+	game.Board().FillSquare(B7, NewPawn(WhiteColor))
+	assert.Contains(game.LegalMovesFrom(E1), LegalMove{From: E1, To: G1})
+	assert.ElementsMatch(
+		[]LegalMove{
+			{From: B7, To: A8, Promotion: QueenIdentifier},
+			{From: B7, To: A8, Promotion: RookIdentifier},
+			{From: B7, To: A8, Promotion: BishopIdentifier},
+			{From: B7, To: A8, Promotion: KnightIdentifier},
+			{From: B7, To: B8, Promotion: QueenIdentifier},
+			{From: B7, To: B8, Promotion: RookIdentifier},
+			{From: B7, To: B8, Promotion: BishopIdentifier},
+			{From: B7, To: B8, Promotion: KnightIdentifier},
+			{From: B7, To: C8, Promotion: QueenIdentifier},
+			{From: B7, To: C8, Promotion: RookIdentifier},
+			{From: B7, To: C8, Promotion: BishopIdentifier},
+			{From: B7, To: C8, Promotion: KnightIdentifier},
+		},
+		game.LegalMovesFrom(B7),
+	)
+
+	// Case: en passant
+	game, _ = testCaseGameWithBoard(loader)
+	game.Move(testPlayerWhite, E2, E4)
+	game.Move(testPlayerBlack, A7, A6)
+	game.Move(testPlayerWhite, E4, E5)
+	game.Move(testPlayerBlack, D7, D5)
+	assert.ElementsMatch(
+		[]LegalMove{{From: E5, To: E6}, {From: E5, To: D6}},
+		game.LegalMovesFrom(E5),
+	)
+
+	// Case: in check, movements leaving king in check are excluded
+	game, _ = testCaseGameWithBoard(loader)
+	game.Move(testPlayerWhite, E2, E4)
+	game.Move(testPlayerBlack, F7, F5)
+	game.Move(testPlayerWhite, D1, H5)
+	assert.ElementsMatch([]LegalMove{{From: G7, To: G6}}, game.LegalMoves())
+
+	// Case: checkmate, no legal movements left
+	game, _ = testCaseGameWithBoard(loader)
+	game.Move(testPlayerWhite, F2, F3)
+	game.Move(testPlayerBlack, E7, E5)
+	game.Move(testPlayerWhite, G2, G4)
+	game.Move(testPlayerBlack, D8, H4)
+	assert.Empty(game.LegalMoves())
+}
@@ -0,0 +1,31 @@
+package engine
+
+func (g *game) Perft(depth int) uint64 {
+	if depth <= 0 {
+		return 1
+	}
+	moves := g.legalMoves(g.turn, g.playerSquares(g.turn), false)
+	if depth == 1 {
+		return uint64(len(moves))
+	}
+	var nodes uint64
+	for _, m := range moves {
+		g.move(g.turn, m.From, m.To, m.Promotion)
+		nodes += g.Perft(depth - 1)
+		g.Rollback(1)
+	}
+	return nodes
+}
+
+func (g *game) Divide(depth int) map[LegalMove]uint64 {
+	divide := map[LegalMove]uint64{}
+	if depth <= 0 {
+		return divide
+	}
+	for _, m := range g.legalMoves(g.turn, g.playerSquares(g.turn), false) {
+		g.move(g.turn, m.From, m.To, m.Promotion)
+		divide[m] = g.Perft(depth - 1)
+		g.Rollback(1)
+	}
+	return divide
+}
@@ -0,0 +1,64 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// perftPositions published perft results, https://www.chessprogramming.org/Perft_Results
+var perftPositions = []struct {
+	name  string
+	fen   string
+	nodes []uint64
+}{
+	{"initial", StartingFEN, []uint64{20, 400, 8902, 197281}},
+	{"kiwipete", "r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1", []uint64{48, 2039, 97862}},
+	{"position 3", "8/2p5/3p4/KP5r/1R3p1k/8/4P1P1/8 w - - 0 1", []uint64{14, 191, 2812, 43238}},
+	{"position 4", "r3k2r/Pppp1ppp/1b3nbN/nP6/BBP1P3/q4N2/Pp1P2PP/R2Q1RK1 w kq - 0 1", []uint64{6, 264, 9467}},
+	{"position 4 mirrored", "r2q1rk1/pP1p2pp/Q4n2/bbp1p3/Np6/1B3NBn/pPPP1PPP/R3K2R b KQ - 0 1", []uint64{6, 264, 9467}},
+	{"position 5", "rnbq1k1r/pp1Pbppp/2p5/8/2B5/8/PPP1NnPP/RNBQK2R w KQ - 1 8", []uint64{44, 1486, 62379}},
+	{"position 6", "r4rk1/1pp1qppp/p1np1n2/2b1p1B1/2B1P1b1/P1NP1N2/1PP1QPPP/R4RK1 w - - 0 10", []uint64{46, 2079, 89890}},
+}
+
+func TestPerft(t *testing.T) {
+	runBoardLoaders(t, testPerft)
+}
+
+func testPerft(t *testing.T, loader BoardLoader) {
+	for _, position := range perftPositions {
+		t.Run(position.name, func(t *testing.T) {
+			game, err := ParseFENWithBoard(position.fen, loader)
+			assert.NoError(t, err)
+			for depth, nodes := range position.nodes {
+				if testing.Short() && nodes > 10000 {
+					break
+				}
+				assert.Equal(t, nodes, game.Perft(depth+1), "depth %d", depth+1)
+			}
+			assert.Equal(t, position.fen, game.FEN())
+		})
+	}
+}
+
+func TestDivide(t *testing.T) {
+	assert := assert.New(t)
+	game, _ := ParseFEN("r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1")
+	divide := game.Divide(2)
+	assert.Equal(48, len(divide))
+	assert.Equal(uint64(43), divide[LegalMove{From: E1, To: G1}])
+	assert.Equal(uint64(43), divide[LegalMove{From: E1, To: C1}])
+	assert.Equal(uint64(44), divide[LegalMove{From: E5, To: F7}])
+	var nodes uint64
+	for _, n := range divide {
+		nodes += n
+	}
+	assert.Equal(uint64(2039), nodes)
+	assert.Equal(0, len(game.Divide(0)))
+}
+
+func TestLegalMoveString(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal("e2e4", LegalMove{From: E2, To: E4}.String())
+	assert.Equal("b7a8n", LegalMove{From: B7, To: A8, Promotion: KnightIdentifier}.String())
+}
@@ -0,0 +1,318 @@
+package engine
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// pgnTagRoster seven tag roster, required in every PGN game and exported in this order
+var pgnTagRoster = []string{"Event", "Site", "Date", "Round", "White", "Black", "Result"}
+
+var pgnResults = map[Result]string{
+	OngoingResult:   "*",
+	WhiteWinsResult: "1-0",
+	BlackWinsResult: "0-1",
+	DrawResult:      "1/2-1/2",
+}
+
+// pgnLineLength maximum length of movetext lines
+const pgnLineLength = 80
+
+// PGNGame a game read from Portable Game Notation
+type PGNGame struct {
+	Tags map[string]string
+	Game Game
+}
+
+func (g *game) PGN(tags map[string]string) string {
+	values := map[string]string{
+		"Event":  g.name,
+		"Site":   "?",
+		"Date":   "????.??.??",
+		"Round":  "?",
+		"White":  g.white.Name,
+		"Black":  g.black.Name,
+		"Result": pgnResults[g.Status().Result],
+	}
+	for _, name := range []string{"Event", "White", "Black"} {
+		if values[name] == "" {
+			values[name] = "?"
+		}
+	}
+
+	// copied, as the backing array is reused by the movements made while generating SAN
+	movements := append([]Movement{}, g.movements...)
+	g.Rollback(len(movements))
+	if fen := g.FEN(); fen != StartingFEN {
+		values["SetUp"] = "1"
+		values["FEN"] = fen
+	}
+	var sans []string
+	blackStarts := g.turn.Color == BlackColor
+	fullmove := g.fullmoveNumber()
+	for _, m := range movements {
+		if m.SAN == "" {
+			m.SAN = g.san(m.LegalMove())
+		}
+		sans = append(sans, m.SAN)
+		g.redo(m)
+	}
+
+	for name, value := range tags {
+		values[name] = value
+	}
+	names := []string{}
+	for name := range values {
+		if !isPGNTagRoster(name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var builder strings.Builder
+	for _, name := range append(pgnTagRoster, names...) {
+		value := strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(values[name])
+		fmt.Fprintf(&builder, "[%s \"%s\"]\n", name, value)
+	}
+	builder.WriteByte('\n')
+
+	var tokens []string
+	for i, san := range sans {
+		switch {
+		case i == 0 && blackStarts:
+			tokens = append(tokens, fmt.Sprintf("%d...", fullmove))
+		case (i%2 == 0) != blackStarts:
+			tokens = append(tokens, fmt.Sprintf("%d.", fullmove))
+		}
+		tokens = append(tokens, san)
+		if (i%2 == 1) != blackStarts {
+			fullmove++
+		}
+	}
+	tokens = append(tokens, values["Result"])
+	line := 0
+	for i, token := range tokens {
+		if i > 0 && line+1+len(token) > pgnLineLength {
+			builder.WriteByte('\n')
+			line = 0
+		} else if i > 0 {
+			builder.WriteByte(' ')
+			line++
+		}
+		builder.WriteString(token)
+		line += len(token)
+	}
+	builder.WriteByte('\n')
+	return builder.String()
+}
+
+func isPGNTagRoster(name string) bool {
+	for _, tag := range pgnTagRoster {
+		if tag == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ParsePGN reads every game in Portable Game Notation from r, replaying its movements.
+// Comments, NAGs, move annotations and variations are skipped
+func ParsePGN(r io.Reader) ([]PGNGame, error) {
+	return ParsePGNWithBoard(r, LoadBoard)
+}
+
+// ParsePGNWithBoard reads every game of a Portable Game Notation input played on the Boards created by loader
+func ParsePGNWithBoard(r io.Reader, loader BoardLoader) ([]PGNGame, error) {
+	bytes, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	p := pgnParser{input: string(bytes), loader: loader}
+	return p.parse()
+}
+
+type pgnParser struct {
+	input  string
+	pos    int
+	loader BoardLoader
+
+	games []PGNGame
+	tags  map[string]string
+	game  *game
+	// variation depth of recursive annotation variations being skipped
+	variation int
+}
+
+func (p *pgnParser) parse() ([]PGNGame, error) {
+	p.games = []PGNGame{}
+	p.tags = map[string]string{}
+	for p.pos < len(p.input) {
+		c := p.input[p.pos]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			p.pos++
+		case c == '%' && (p.pos == 0 || p.input[p.pos-1] == '\n'), c == ';':
+			p.skipUntil("\n")
+		case c == '{':
+			if !p.skipUntil("}") {
+				return nil, p.errorf("comment is not closed")
+			}
+		case c == '(':
+			p.variation++
+			p.pos++
+		case c == ')':
+			if p.variation == 0 {
+				return nil, p.errorf("unexpected \")\"")
+			}
+			p.variation--
+			p.pos++
+		case c == '[' && p.variation == 0:
+			if p.game != nil {
+				if err := p.endGame(); err != nil {
+					return nil, err
+				}
+			}
+			if err := p.parseTag(); err != nil {
+				return nil, err
+			}
+		default:
+			if err := p.parseSymbol(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if p.variation > 0 {
+		return nil, p.errorf("variation is not closed")
+	}
+	if p.game != nil || len(p.tags) > 0 {
+		if err := p.endGame(); err != nil {
+			return nil, err
+		}
+	}
+	return p.games, nil
+}
+
+// skipUntil moves after the next end string, returns false if not found
+func (p *pgnParser) skipUntil(end string) bool {
+	i := strings.Index(p.input[p.pos:], end)
+	if i < 0 {
+		p.pos = len(p.input)
+		return false
+	}
+	p.pos += i + len(end)
+	return true
+}
+
+// parseTag parses a tag pair, i.e. [White "Fischer, Robert J."]
+func (p *pgnParser) parseTag() error {
+	start := p.pos
+	p.pos++
+	i := p.pos
+	for i < len(p.input) && !strings.ContainsRune(" \t\r\n\"]", rune(p.input[i])) {
+		i++
+	}
+	name := p.input[p.pos:i]
+	for i < len(p.input) && strings.ContainsRune(" \t\r\n", rune(p.input[i])) {
+		i++
+	}
+	if name == "" || i == len(p.input) || p.input[i] != '"' {
+		return p.errorf("invalid tag at position %d", start)
+	}
+	var value strings.Builder
+	for i++; i < len(p.input) && p.input[i] != '"'; i++ {
+		if p.input[i] == '\\' && i+1 < len(p.input) {
+			i++
+		}
+		value.WriteByte(p.input[i])
+	}
+	for i++; i < len(p.input) && strings.ContainsRune(" \t\r\n", rune(p.input[i])); i++ {
+	}
+	if i >= len(p.input) || p.input[i] != ']' {
+		return p.errorf("tag %s is not closed", name)
+	}
+	p.tags[name] = value.String()
+	p.pos = i + 1
+	return nil
+}
+
+// moveNumberRegexp move number indication prefixing a token
+var moveNumberRegexp = regexp.MustCompile(`^\d+(\.+|$)`)
+
+func (p *pgnParser) parseSymbol() error {
+	start := p.pos
+	for p.pos < len(p.input) && !strings.ContainsRune(" \t\r\n{}()[];", rune(p.input[p.pos])) {
+		p.pos++
+	}
+	token := p.input[start:p.pos]
+	if p.variation > 0 || strings.HasPrefix(token, "$") {
+		return nil
+	}
+	switch token {
+	case "1-0", "0-1", "1/2-1/2", "*":
+		if _, ok := p.tags["Result"]; !ok {
+			p.tags["Result"] = token
+		}
+		return p.endGame()
+	}
+	// move number indication, i.e. "12." or "12..." or "12.e4", castling "0-0" is a move
+	token = moveNumberRegexp.ReplaceAllString(token, "")
+	if token == "" {
+		return nil
+	}
+	if err := p.startGame(); err != nil {
+		return err
+	}
+	m, err := p.game.ParseSAN(token)
+	if err != nil {
+		return p.errorf("%v", err)
+	}
+	if _, err := p.game.Move(p.game.turn, m.From, m.To, m.Promotion); err != nil {
+		return p.errorf("move %q: %v", token, err)
+	}
+	return nil
+}
+
+// startGame creates the game from tags if not created yet
+func (p *pgnParser) startGame() error {
+	if p.game != nil {
+		return nil
+	}
+	white := Player{Name: p.tags["White"], Color: WhiteColor}
+	black := Player{Name: p.tags["Black"], Color: BlackColor}
+	var g Game
+	var err error
+	if fen, ok := p.tags["FEN"]; ok {
+		g, err = ParseFENWithBoard(fen, p.loader)
+	} else {
+		g, err = NewGameWithBoard(p.tags["Event"], black, white, p.loader)
+	}
+	if err != nil {
+		return p.errorf("%v", err)
+	}
+	p.game = g.(*game)
+	p.game.name = p.tags["Event"]
+	p.game.white, p.game.black = white, black
+	p.game.turn = white
+	if g.Turn().Color == BlackColor {
+		p.game.turn = black
+	}
+	return nil
+}
+
+func (p *pgnParser) endGame() error {
+	if err := p.startGame(); err != nil {
+		return err
+	}
+	p.games = append(p.games, PGNGame{Tags: p.tags, Game: p.game})
+	p.tags = map[string]string{}
+	p.game = nil
+	return nil
+}
+
+func (p *pgnParser) errorf(format string, a ...interface{}) error {
+	return fmt.Errorf("invalid PGN game %d: %s", len(p.games)+1, fmt.Sprintf(format, a...))
+}
@@ -0,0 +1,170 @@
+package engine
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPGN(t *testing.T) {
+	runBoardLoaders(t, testPGN)
+}
+
+func testPGN(t *testing.T, loader BoardLoader) {
+	assert := assert.New(t)
+
+	// Case: ongoing game without movements
+	game, _ := testCaseGameWithBoard(loader)
+	assert.Equal(`[Event "asaditosGame"]
+[Site "?"]
+[Date "????.??.??"]
+[Round "?"]
+[White "Luis"]
+[Black "Joel"]
+[Result "*"]
+
+*
+`, game.PGN(nil))
+
+	// Case: checkmate, with tags
+	game.Move(testPlayerWhite, E2, E4)
+	game.Move(testPlayerBlack, E7, E5)
+	game.Move(testPlayerWhite, F1, C4)
+	game.Move(testPlayerBlack, B8, C6)
+	game.Move(testPlayerWhite, D1, H5)
+	game.Move(testPlayerBlack, G8, F6)
+	game.Move(testPlayerWhite, H5, F7)
+	assert.Equal(`[Event "Casual \"blitz\""]
+[Site "?"]
+[Date "2021.06.01"]
+[Round "?"]
+[White "Luis"]
+[Black "Joel"]
+[Result "1-0"]
+[Annotator "me"]
+
+1. e4 e5 2. Bc4 Nc6 3. Qh5 Nf6 4. Qxf7# 1-0
+`, game.PGN(map[string]string{"Event": `Casual "blitz"`, "Date": "2021.06.01", "Annotator": "me"}))
+	assert.Equal(7, len(game.Movements()))
+
+	// Case: started from a position, castling, promotion, disambiguation and black starts
+	game, _ = ParseFENWithBoard("r3k2r/6P1/8/8/8/8/8/R3K1NR b KQkq - 0 30", loader)
+	game.Move(testPlayerBlack, E8, C8)
+	game.Move(testPlayerWhite, G1, F3)
+	game.Move(testPlayerBlack, H8, F8)
+	game.Move(testPlayerWhite, G7, F8, QueenIdentifier)
+	game.Move(testPlayerBlack, D8, F8)
+	game.Move(testPlayerWhite, E1, G1)
+	assert.Equal(`[Event "?"]
+[Site "?"]
+[Date "????.??.??"]
+[Round "?"]
+[White "?"]
+[Black "?"]
+[Result "*"]
+[FEN "r3k2r/6P1/8/8/8/8/8/R3K1NR b KQkq - 0 30"]
+[SetUp "1"]
+
+30... O-O-O 31. Nf3 Rhf8 32. gxf8=Q Rxf8 33. O-O *
+`, game.PGN(nil))
+
+	// Case: long games are wrapped
+	game, _ = testCaseGameWithBoard(loader)
+	for i := 0; i < 6; i++ {
+		game.Move(testPlayerWhite, G1, F3)
+		game.Move(testPlayerBlack, G8, F6)
+		game.Move(testPlayerWhite, F3, G1)
+		game.Move(testPlayerBlack, F6, G8)
+	}
+	for _, line := range strings.Split(game.PGN(nil), "\n") {
+		assert.LessOrEqual(len(line), 80)
+	}
+}
+
+func TestParsePGN(t *testing.T) {
+	assert := assert.New(t)
+
+	pgn := `% exported by hand
+[Event "F/S Return Match"]
+[Site "Belgrade, Serbia JUG"]
+[Date "1992.11.04"]
+[Round "29"]
+[White "Fischer, Robert J."]
+[Black "Spassky, Boris V."]
+[Result "1/2-1/2"]
+
+1. e4 e5 2. Nf3 Nc6 3. Bb5 {This opening is called the Ruy Lopez.} 3... a6
+4. Ba4 Nf6 5. O-O Be7 6. Re1 b5 7. Bb3 d6 8. c3 O-O 9. h3 Nb8 10. d4 Nbd7
+11. c4 c6 12. cxb5 axb5 13. Nc3 Bb7 14. Bg5 b4 15. Nb1 h6 16. Bh4 c5 17. dxe5
+Nxe4 18. Bxe7 Qxe7 19. exd6 Qf6 20. Nbd2 Nxd6 21. Nc4 Nxc4 22. Bxc4 Nb6
+23. Ne5 Rae8 24. Bxf7+ Rxf7 25. Nxf7 Rxe1+ 26. Qxe1 Kxf7 27. Qe3 Qg5 28. Qxg5
+hxg5 29. b3 Ke6 30. a3 Kd6 31. axb4 cxb4 32. Ra5 Nd5 33. f3 Bc8 34. Kf2 Bf5
+35. Ra7 g6 36. Ra6+ Kc5 37. Ke1 Nf4 38. g3 Nxh3 39. Kd2 Kb5 40. Rd6 Kc5 41. Ra6
+Nf2 42. g4 Bd3 43. Re6 1/2-1/2
+
+[Event "Scholar \"mate\""]
+[White "A"]
+[Black "B"]
+[Result "1-0"]
+
+1.e4 $1 e5 (1...c5 2.Nf3 {Sicilian}) 2.Bc4!? Nc6 ; a comment
+3.Qh5 Nf6?? 4.Qxf7# 1-0
+
+[Event "From position"]
+[SetUp "1"]
+[FEN "4k3/P7/8/8/8/8/8/4K3 w - - 0 1"]
+
+1. a8=Q+ Kd7 *
+`
+	games, err := ParsePGN(strings.NewReader(pgn))
+	assert.NoError(err)
+	assert.Equal(3, len(games))
+
+	assert.Equal("Fischer, Robert J.", games[0].Tags["White"])
+	assert.Equal("Spassky, Boris V.", games[0].Game.Turn().Name)
+	assert.Equal(85, len(games[0].Game.Movements()))
+	assert.Equal("8/8/4R1p1/2k3p1/1p4P1/1P1b1P2/3K1n2/8 b - - 2 43", games[0].Game.FEN())
+	export := games[0].Game.PGN(games[0].Tags)
+	assert.Contains(export, "[Round \"29\"]\n[White \"Fischer, Robert J.\"]\n[Black \"Spassky, Boris V.\"]\n[Result \"1/2-1/2\"]\n")
+	assert.Contains(export, "3. Bb5 a6 4. Ba4 Nf6 5. O-O Be7")
+	assert.Contains(export, "10. d4 Nbd7")
+	assert.Contains(export, "24. Bxf7+ Rxf7")
+	assert.True(strings.HasSuffix(export, "43. Re6 1/2-1/2\n"))
+
+	assert.Equal(`Scholar "mate"`, games[1].Tags["Event"])
+	assert.Equal(Status{Result: WhiteWinsResult, Reason: CheckmateReason}, games[1].Game.Status())
+
+	assert.Equal("Q7/3k4/8/8/8/8/8/4K3 w - - 1 2", games[2].Game.FEN())
+
+	// Case: castling written with zeros
+	games, err = ParsePGN(strings.NewReader("1. e4 e5 2. Nf3 Nc6 3. Bc4 Bc5 4. 0-0 *"))
+	assert.NoError(err)
+	assert.Equal("r1bqk1nr/pppp1ppp/2n5/2b1p3/2B1P3/5N2/PPPP1PPP/RNBQ1RK1 b kq - 5 4", games[0].Game.FEN())
+
+	// Case: empty
+	games, err = ParsePGN(strings.NewReader(""))
+	assert.NoError(err)
+	assert.Equal(0, len(games))
+
+	// Case: games played on the board given, from the starting position and from a FEN
+	games, err = ParsePGNWithBoard(strings.NewReader("1. e4 e5 *\n\n[SetUp \"1\"]\n[FEN \"4k3/8/8/8/8/8/8/4K2R w K - 0 1\"]\n\n1. O-O *"), LoadBitboard)
+	assert.NoError(err)
+	assert.Equal(2, len(games))
+	assert.IsType(&bitboard{}, games[0].Game.Board())
+	assert.Equal("rnbqkbnr/pppp1ppp/8/4p3/4P3/8/PPPP1PPP/RNBQKBNR w KQkq e6 0 2", games[0].Game.FEN())
+	assert.IsType(&bitboard{}, games[1].Game.Board())
+	assert.Equal("4k3/8/8/8/8/8/8/5RK1 b - - 1 1", games[1].Game.FEN())
+
+	// Case: malformed
+	for pgn, msg := range map[string]string{
+		"1. e4 e5 2. Ke3 *":     "invalid PGN game 1: illegal SAN move \"Ke3\"",
+		"1. e4 {never closed":   "invalid PGN game 1: comment is not closed",
+		"1. e4 (1. d4 *":        "invalid PGN game 1: variation is not closed",
+		"[White \"A\"\n1. e4 *": "invalid PGN game 1: tag White is not closed",
+		"* 1. e4 e5 2. Qh5 Nc6 3. Bc4 Nf6 4. Qxf7# Ke7 1-0": "invalid PGN game 2: illegal SAN move \"Ke7\"",
+	} {
+		_, err := ParsePGN(strings.NewReader(pgn))
+		assert.EqualError(err, msg)
+	}
+}
@@ -46,18 +46,68 @@ func NewKing(color Color) Piece {
 	}
 }
 
+// kingEatableInSquare returns true if a king of color placed in TO Square could be eaten
 func kingEatableInSquare(color Color, b Board, m []Movement, to Square) bool {
+	oponent := WhiteColor
+	if color == WhiteColor {
+		oponent = BlackColor
+	}
+	if fb, ok := b.(fastBoard); ok {
+		return fb.attacked(to.SquareIdentifier, oponent)
+	}
+
+	// TO Square is asked as if the king was already there, even if it holds a piece to eat
+	to.Empty = false
+	to.Piece = kings[color]
 	// Loop to find if any piece can eat king in TO Square
 	for _, square := range b.Squares() {
 		if !square.Empty && square.Piece.Color() != color {
-			if square.Piece.CanMove(b, m, square, to) {
-				return true
+			switch square.Piece.(type) {
+			case *king:
+				// a king attacks adjacent squares, asking CanMove would recurse over both kings
+				if kingStep(square.Coordinates, to.Coordinates) {
+					return true
+				}
+			case *pawn:
+				// pawns attack diagonally even when the square is empty
+				if pawnAttacks(square.Piece.Color(), square.Coordinates, to.Coordinates) {
+					return true
+				}
+			default:
+				if square.Piece.CanMove(b, m, square, to) {
+					return true
+				}
 			}
 		}
 	}
 	return false
 }
 
+var kings = map[Color]Piece{WhiteColor: NewKing(WhiteColor), BlackColor: NewKing(BlackColor)}
+
+// kingStep returns true if to is adjacent to from
+func kingStep(from, to Coordinate) bool {
+	dx := int(to.X) - int(from.X)
+	dy := int(to.Y) - int(from.Y)
+	if dx == 0 && dy == 0 {
+		return false
+	}
+	return dx >= -1 && dx <= 1 && dy >= -1 && dy <= 1
+}
+
+// pawnAttacks returns true if a pawn of color in from attacks to
+func pawnAttacks(color Color, from, to Coordinate) bool {
+	dx := int(to.X) - int(from.X)
+	dy := int(to.Y) - int(from.Y)
+	if dx != 1 && dx != -1 {
+		return false
+	}
+	if color == WhiteColor {
+		return dy == 1
+	}
+	return dy == -1
+}
+
 func (k *king) CanMove(b Board, m []Movement, from, to Square) bool {
 	if from.Empty {
 		return false
@@ -66,9 +116,8 @@ func (k *king) CanMove(b Board, m []Movement, from, to Square) bool {
 		return false
 	}
 
-	if !((to.Coordinates.X == from.Coordinates.X+1 || to.Coordinates.X == from.Coordinates.X-1) &&
-		(to.Coordinates.Y == from.Coordinates.Y+1 || to.Coordinates.Y == from.Coordinates.Y-1) &&
-		(to.Empty || !to.Empty && to.Piece.Color() != from.Piece.Color())) {
+	if !kingStep(from.Coordinates, to.Coordinates) ||
+		!to.Empty && to.Piece.Color() == from.Piece.Color() {
 		return false
 	}
 
@@ -319,7 +368,6 @@ func NewPawn(color Color) Piece {
 	}
 }
 
-// TODO: PawnCanMove [En passant , Handle when reachs Coordinates limits]
 func (p *pawn) CanMove(board Board, movements []Movement, from, to Square) bool {
 	if from.Empty {
 		return false
@@ -332,9 +380,9 @@ func (p *pawn) CanMove(board Board, movements []Movement, from, to Square) bool
 		// First movement , not eating
 		if from.Coordinates.Y == 1 &&
 			from.Coordinates.X == to.Coordinates.X &&
-			to.Coordinates.Y >= from.Coordinates.Y+2 &&
-			to.Empty {
-			// BUG : when there's a piece in position X+1 and pawn moves two spaces, it returns true
+			to.Coordinates.Y == from.Coordinates.Y+2 &&
+			to.Empty &&
+			board.Squares()[CoordinateToSquareIdentifier(Coordinate{X: from.Coordinates.X, Y: from.Coordinates.Y + 1})].Empty {
 			return true
 		}
 
@@ -352,13 +400,19 @@ func (p *pawn) CanMove(board Board, movements []Movement, from, to Square) bool
 			to.Piece.Color() != from.Piece.Color() {
 			return true
 		}
+
+		// En passant
+		if pawnAttacks(WhiteColor, from.Coordinates, to.Coordinates) && to.Empty &&
+			enPassantAllowed(movements, from, to) {
+			return true
+		}
 	} else if from.Piece.Color() == BlackColor {
 		// First movement , not eating
 		if from.Coordinates.Y == 6 &&
 			from.Coordinates.X == to.Coordinates.X &&
-			to.Coordinates.Y >= from.Coordinates.Y-2 &&
-			to.Empty {
-			// BUG : when there's a piece in position X-1 and pawn moves two spaces, it returns true
+			to.Coordinates.Y == from.Coordinates.Y-2 &&
+			to.Empty &&
+			board.Squares()[CoordinateToSquareIdentifier(Coordinate{X: from.Coordinates.X, Y: from.Coordinates.Y - 1})].Empty {
 			return true
 		}
 
@@ -376,10 +430,44 @@ func (p *pawn) CanMove(board Board, movements []Movement, from, to Square) bool
 			to.Piece.Color() != from.Piece.Color() {
 			return true
 		}
+
+		// En passant
+		if pawnAttacks(BlackColor, from.Coordinates, to.Coordinates) && to.Empty &&
+			enPassantAllowed(movements, from, to) {
+			return true
+		}
 	}
 	return false
 }
 
+// enPassantAllowed returns true if last movement was an opposing pawn advancing two squares,
+// passing through to and landing next to from
+func enPassantAllowed(movements []Movement, from, to Square) bool {
+	if len(movements) == 0 {
+		return false
+	}
+	last := movements[len(movements)-1]
+	if last.PieceMoved == nil ||
+		last.PieceMoved.Identifier() != PawnIdentifier ||
+		last.PieceMoved.Color() == from.Piece.Color() {
+		return false
+	}
+	lastFrom := SquareIdentifierToCoordinate(last.From)
+	lastTo := SquareIdentifierToCoordinate(last.To)
+	return lastFrom.X == to.Coordinates.X &&
+		lastTo.X == to.Coordinates.X &&
+		lastTo.Y == from.Coordinates.Y &&
+		int(lastFrom.Y)+int(lastTo.Y) == 2*int(to.Coordinates.Y)
+}
+
+// enPassantSquare returns the square of the pawn eaten en passant when moving from, to
+func enPassantSquare(from, to SquareIdentifier) SquareIdentifier {
+	return CoordinateToSquareIdentifier(Coordinate{
+		X: SquareIdentifierToCoordinate(to).X,
+		Y: SquareIdentifierToCoordinate(from).Y,
+	})
+}
+
 func (p *pawn) String() string {
 	if p.Color() == WhiteColor {
 		return "WP"
@@ -51,6 +51,11 @@ func TestKingCanMove(t *testing.T) {
 		SquareIdentifier: D4,
 	}
 
+	// squares are asked to other pieces as if the king was already there
+	c3WithKing, a1WithKing := c3, a1
+	c3WithKing.Empty, c3WithKing.Piece = false, whiteKing
+	a1WithKing.Empty, a1WithKing.Piece = false, whiteKing
+
 	squares := map[SquareIdentifier]Square{
 		A1: a1, // Empty
 		B2: b2, // WhiteKing
@@ -68,7 +73,7 @@ func TestKingCanMove(t *testing.T) {
 		Return(BlackColor)
 	mockBlackPieceD4.
 		EXPECT().
-		CanMove(mockBoard, movements, d4, c3).
+		CanMove(mockBoard, movements, d4, c3WithKing).
 		Return(true)
 
 	// Cannot move due to king being eaten at c3 by mockBlackPieceD4
@@ -84,7 +89,7 @@ func TestKingCanMove(t *testing.T) {
 		Return(BlackColor)
 	mockBlackPieceD4.
 		EXPECT().
-		CanMove(mockBoard, movements, d4, a1).
+		CanMove(mockBoard, movements, d4, a1WithKing).
 		Return(false)
 
 	// Valid movement
@@ -420,8 +425,29 @@ func TestPawnCanMove(t *testing.T) {
 		SquareIdentifier: A4,
 	}
 	board := NewMockBoard(ctrl)
+	board.
+		EXPECT().
+		Squares().
+		Return(Squares{A3: Square{Empty: true, Coordinates: Coordinate{0, 2}, SquareIdentifier: A3}})
 	assert.True(whitePawn.CanMove(board, movements, square1, square2))
 
+	// when first movement, cannot jump over a piece
+	board = NewMockBoard(ctrl)
+	board.
+		EXPECT().
+		Squares().
+		Return(Squares{A3: Square{Empty: false, Piece: blackPawn, Coordinates: Coordinate{0, 2}, SquareIdentifier: A3}})
+	assert.False(whitePawn.CanMove(board, movements, square1, square2))
+
+	// when first movement, cannot advance three spaces
+	square2 = Square{
+		Empty:            true,
+		Coordinates:      Coordinate{0, 4},
+		SquareIdentifier: A5,
+	}
+	board = NewMockBoard(ctrl)
+	assert.False(whitePawn.CanMove(board, movements, square1, square2))
+
 	// when moving to empty
 	movements = []Movement{}
 	square1 = Square{
@@ -470,6 +496,10 @@ func TestPawnCanMove(t *testing.T) {
 		SquareIdentifier: F5,
 	}
 	board = NewMockBoard(ctrl)
+	board.
+		EXPECT().
+		Squares().
+		Return(Squares{F6: Square{Empty: true, Coordinates: Coordinate{5, 5}, SquareIdentifier: F6}})
 	assert.True(blackPawn.CanMove(board, movements, square1, square2))
 
 	// when moving to empty
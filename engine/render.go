@@ -0,0 +1,143 @@
+package engine
+
+import (
+	"strings"
+)
+
+// ANSI escape sequences used to render colored boards
+const (
+	ansiReset       = "\x1b[0m"
+	ansiLightSquare = "\x1b[48;5;180m"
+	ansiDarkSquare  = "\x1b[48;5;137m"
+	ansiHighlight   = "\x1b[48;5;186m"
+	ansiCheck       = "\x1b[48;5;160m"
+	ansiWhitePiece  = "\x1b[38;5;231m"
+	ansiBlackPiece  = "\x1b[38;5;16m"
+)
+
+var pieceLetters = map[PieceIdentifier]string{
+	PawnIdentifier:   "P",
+	KnightIdentifier: "N",
+	BishopIdentifier: "B",
+	RookIdentifier:   "R",
+	QueenIdentifier:  "Q",
+	KingIdentifier:   "K",
+}
+
+var whiteGlyphs = map[PieceIdentifier]string{
+	PawnIdentifier:   "♙",
+	KnightIdentifier: "♘",
+	BishopIdentifier: "♗",
+	RookIdentifier:   "♖",
+	QueenIdentifier:  "♕",
+	KingIdentifier:   "♔",
+}
+
+var blackGlyphs = map[PieceIdentifier]string{
+	PawnIdentifier:   "♟",
+	KnightIdentifier: "♞",
+	BishopIdentifier: "♝",
+	RookIdentifier:   "♜",
+	QueenIdentifier:  "♛",
+	KingIdentifier:   "♚",
+}
+
+// RenderOptions board rendering options, the zero value renders ASCII letters from white side,
+// uppercase for white pieces and lowercase for black ones
+type RenderOptions struct {
+	// Unicode draws pieces with glyphs, i.e. ♔, instead of letters
+	Unicode bool
+	// Color draws squares and pieces with ANSI colors
+	Color bool
+	// Coordinates draws rank and file labels
+	Coordinates bool
+	// Flip draws the board from black side
+	Flip bool
+	// Highlight squares highlighted, i.e. the last movement squares,
+	// drawn within brackets when not colored
+	Highlight []SquareIdentifier
+	// Check square of the king in check, 0 for none, drawn within parentheses when not colored
+	Check SquareIdentifier
+}
+
+// renderSquares renders squares as text lines, one per rank
+func renderSquares(squares Squares, options RenderOptions) string {
+	highlights := map[SquareIdentifier]bool{}
+	for _, loc := range options.Highlight {
+		highlights[loc] = true
+	}
+	ranks := []uint8{7, 6, 5, 4, 3, 2, 1, 0}
+	files := []uint8{0, 1, 2, 3, 4, 5, 6, 7}
+	if options.Flip {
+		ranks, files = files, ranks
+	}
+
+	var builder strings.Builder
+	for _, y := range ranks {
+		var line strings.Builder
+		if options.Coordinates {
+			line.WriteString(string(rune('1'+y)) + " ")
+		}
+		for _, x := range files {
+			loc := CoordinateToSquareIdentifier(Coordinate{X: x, Y: y})
+			square := squares[loc]
+			if !options.Color {
+				left, right := " ", " "
+				switch {
+				case loc == options.Check:
+					left, right = "(", ")"
+				case highlights[loc]:
+					left, right = "[", "]"
+				}
+				line.WriteString(left + pieceSymbol(square, options) + right)
+				continue
+			}
+			background := ansiDarkSquare
+			if (x+y)%2 == 1 {
+				background = ansiLightSquare
+			}
+			switch {
+			case loc == options.Check:
+				background = ansiCheck
+			case highlights[loc]:
+				background = ansiHighlight
+			}
+			foreground := ansiWhitePiece
+			if !square.Empty && square.Piece.Color() == BlackColor {
+				foreground = ansiBlackPiece
+			}
+			line.WriteString(background + foreground + " " + pieceSymbol(square, options) + " " + ansiReset)
+		}
+		builder.WriteString(strings.TrimRight(line.String(), " ") + "\n")
+	}
+	if options.Coordinates {
+		var line strings.Builder
+		line.WriteString("  ")
+		for _, x := range files {
+			line.WriteString(" " + string(rune('a'+x)) + " ")
+		}
+		builder.WriteString(strings.TrimRight(line.String(), " ") + "\n")
+	}
+	return builder.String()
+}
+
+// pieceSymbol returns the letter or glyph of the square piece, "." for an empty square unless colored.
+// Colored glyphs are filled for both colors, the foreground color tells them apart
+func pieceSymbol(square Square, options RenderOptions) string {
+	if square.Empty {
+		if options.Color {
+			return " "
+		}
+		return "."
+	}
+	identifier, color := square.Piece.Identifier(), square.Piece.Color()
+	switch {
+	case options.Unicode && (options.Color || color == BlackColor):
+		return blackGlyphs[identifier]
+	case options.Unicode:
+		return whiteGlyphs[identifier]
+	case color == BlackColor:
+		return strings.ToLower(pieceLetters[identifier])
+	}
+	return pieceLetters[identifier]
+}
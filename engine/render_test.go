@@ -0,0 +1,62 @@
+package engine
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRender(t *testing.T) {
+	assert := assert.New(t)
+	board := testableBoard()
+
+	assert.Equal(` r  n  b  q  k  b  n  r
+ p  p  p  p  p  p  p  p
+ .  .  .  .  .  .  .  .
+ .  .  .  .  .  .  .  .
+ .  .  .  .  .  .  .  .
+ .  .  .  .  .  .  .  .
+ P  P  P  P  P  P  P  P
+ R  N  B  Q  K  B  N  R
+`, board.Render(RenderOptions{}))
+
+	assert.Equal(`1  ♖  ♘  ♗  ♔  ♕  ♗  ♘  ♖
+2  ♙  ♙  ♙  ♙  ♙  ♙  ♙  ♙
+3  .  .  .  .  .  .  .  .
+4  .  .  .  .  .  .  .  .
+5  .  .  .  .  .  .  .  .
+6  .  .  .  .  .  .  .  .
+7  ♟  ♟  ♟  ♟  ♟  ♟  ♟  ♟
+8  ♜  ♞  ♝  ♚  ♛  ♝  ♞  ♜
+   h  g  f  e  d  c  b  a
+`, board.Render(RenderOptions{Unicode: true, Coordinates: true, Flip: true}))
+
+	// Case: colored squares
+	lines := strings.Split(board.Render(RenderOptions{Color: true, Unicode: true}), "\n")
+	assert.Len(lines, 9)
+	assert.True(strings.HasPrefix(lines[7], ansiDarkSquare+ansiWhitePiece+" ♜ "+ansiReset+ansiLightSquare+ansiWhitePiece+" ♞ "+ansiReset))
+	assert.True(strings.HasPrefix(lines[0], ansiLightSquare+ansiBlackPiece+" ♜ "+ansiReset))
+	assert.Contains(lines[3], ansiLightSquare+ansiWhitePiece+"   "+ansiReset)
+
+	// Case: last movement and king in check highlighted
+	game, _ := ParseFEN(StartingFEN)
+	for _, san := range []string{"f3", "e5", "g4", "Qh4"} {
+		m, err := game.ParseSAN(san)
+		assert.NoError(err)
+		game.Move(game.Turn(), m.From, m.To, m.Promotion)
+	}
+	assert.Equal(`8  r  n  b [.] k  b  n  r
+7  p  p  p  p  .  p  p  p
+6  .  .  .  .  .  .  .  .
+5  .  .  .  .  p  .  .  .
+4  .  .  .  .  .  .  P [q]
+3  .  .  .  .  .  P  .  .
+2  P  P  P  P  P  .  .  P
+1  R  N  B  Q (K) B  N  R
+   a  b  c  d  e  f  g  h
+`, game.Render(RenderOptions{Coordinates: true}))
+	lines = strings.Split(game.Render(RenderOptions{Color: true}), "\n")
+	assert.Contains(lines[4], ansiHighlight+ansiBlackPiece+" q ")
+	assert.Contains(lines[7], ansiCheck+ansiWhitePiece+" K ")
+}
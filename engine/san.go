@@ -0,0 +1,171 @@
+package engine
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var sanRegexp = regexp.MustCompile(`^([KQRBN])?([a-h])?([1-8])?x?([a-h][1-8])(?:=?([QRBN]))?$`)
+
+// sanPieceLetter returns the uppercase letter of the piece, empty for pawns
+func sanPieceLetter(i PieceIdentifier) string {
+	if i == PawnIdentifier {
+		return ""
+	}
+	return strings.ToUpper(string(fenPieces[i]))
+}
+
+func (g *game) SAN(m LegalMove) string {
+	square := g.square(m.From)
+	if square.Empty || square.Piece.Color() != g.turn.Color {
+		return ""
+	}
+	return g.san(m)
+}
+
+// san returns the Standard Algebraic Notation of a move of the player in turn, i.e. "Nbd7", "exd5", "e8=Q+"
+func (g *game) san(m LegalMove) string {
+	squareFrom, squareTo := g.square(m.From), g.square(m.To)
+	piece := squareFrom.Piece
+
+	var builder strings.Builder
+	if c, ok := findCastling(piece.Color(), m.From, m.To); ok && piece.Identifier() == KingIdentifier {
+		if c.kingTo == G1 || c.kingTo == G8 {
+			builder.WriteString("O-O")
+		} else {
+			builder.WriteString("O-O-O")
+		}
+	} else {
+		to := strings.ToLower(SquareIdentifierToString(m.To))
+		capture := !squareTo.Empty || piece.Identifier() == PawnIdentifier && squareFrom.Coordinates.X != squareTo.Coordinates.X
+		if piece.Identifier() == PawnIdentifier {
+			if capture {
+				builder.WriteByte('a' + squareFrom.Coordinates.X)
+			}
+		} else {
+			builder.WriteString(sanPieceLetter(piece.Identifier()))
+			builder.WriteString(g.sanDisambiguation(squareFrom, m.To))
+		}
+		if capture {
+			builder.WriteByte('x')
+		}
+		builder.WriteString(to)
+		if m.Promotion != 0 {
+			builder.WriteString("=" + sanPieceLetter(m.Promotion))
+		}
+	}
+
+	player := g.turn
+	if ok, _ := g.move(player, m.From, m.To, m.Promotion); ok {
+		if g.IsCheckBy(player) {
+			if g.hasLegalMove(g.turn) {
+				builder.WriteByte('+')
+			} else {
+				builder.WriteByte('#')
+			}
+		}
+		g.Rollback(1)
+	}
+	return builder.String()
+}
+
+// sanDisambiguation returns file, rank or both of squareFrom when another piece of the same kind can move to the same square
+func (g *game) sanDisambiguation(squareFrom Square, to SquareIdentifier) string {
+	var others []Square
+	for _, square := range g.playerSquares(g.turn) {
+		if square.SquareIdentifier != squareFrom.SquareIdentifier && square.Piece.Identifier() == squareFrom.Piece.Identifier() {
+			others = append(others, square)
+		}
+	}
+	sameFile, sameRank, ambiguous := false, false, false
+	for _, m := range g.legalMoves(g.turn, others, false) {
+		if m.To != to {
+			continue
+		}
+		ambiguous = true
+		from := SquareIdentifierToCoordinate(m.From)
+		sameFile = sameFile || from.X == squareFrom.Coordinates.X
+		sameRank = sameRank || from.Y == squareFrom.Coordinates.Y
+	}
+	loc := strings.ToLower(SquareIdentifierToString(squareFrom.SquareIdentifier))
+	switch {
+	case !ambiguous:
+		return ""
+	case !sameFile:
+		return loc[:1]
+	case !sameRank:
+		return loc[1:]
+	default:
+		return loc
+	}
+}
+
+func (g *game) ParseSAN(san string) (LegalMove, error) {
+	notation := strings.TrimRight(san, "+#!?")
+	var piece PieceIdentifier
+	var fromFile, fromRank, to, promotion string
+	switch notation {
+	case "O-O", "0-0", "O-O-O", "0-0-0":
+		return g.parseCastling(san, len(notation) == 5)
+	}
+	matches := sanRegexp.FindStringSubmatch(notation)
+	if matches == nil {
+		return LegalMove{}, fmt.Errorf("invalid SAN move %q", san)
+	}
+	piece = PawnIdentifier
+	if matches[1] != "" {
+		piece = fenToPiece(strings.ToLower(matches[1])[0]).Identifier()
+	}
+	fromFile, fromRank, to, promotion = matches[2], matches[3], matches[4], matches[5]
+
+	var promotionIdentifier PieceIdentifier
+	if promotion != "" {
+		promotionIdentifier = fenToPiece(strings.ToLower(promotion)[0]).Identifier()
+	}
+	var candidates []LegalMove
+	for _, m := range g.LegalMoves() {
+		from := strings.ToLower(SquareIdentifierToString(m.From))
+		if g.square(m.From).Piece.Identifier() != piece ||
+			strings.ToLower(SquareIdentifierToString(m.To)) != to ||
+			m.Promotion != promotionIdentifier ||
+			fromFile != "" && from[:1] != fromFile ||
+			fromRank != "" && from[1:] != fromRank {
+			continue
+		}
+		candidates = append(candidates, m)
+	}
+	switch len(candidates) {
+	case 0:
+		return LegalMove{}, fmt.Errorf("illegal SAN move %q", san)
+	case 1:
+		return candidates[0], nil
+	default:
+		return LegalMove{}, fmt.Errorf("ambiguous SAN move %q", san)
+	}
+}
+
+// parseCastling resolves the castling of the player in turn, king side unless queenSide,
+// san is only used in errors
+func (g *game) parseCastling(san string, queenSide bool) (LegalMove, error) {
+	from, to := E1, G1
+	if queenSide {
+		to = C1
+	}
+	if g.turn.Color == BlackColor {
+		from, to = E8, G8
+		if queenSide {
+			to = C8
+		}
+	}
+	c, _ := findCastling(g.turn.Color, from, to)
+	square := g.square(c.kingFrom)
+	if !square.Empty && square.Piece.Identifier() == KingIdentifier {
+		for _, m := range g.LegalMoves() {
+			if m.From == c.kingFrom && m.To == c.kingTo {
+				return m, nil
+			}
+		}
+	}
+	return LegalMove{}, fmt.Errorf("illegal SAN move %q", san)
+}
@@ -0,0 +1,112 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSAN(t *testing.T) {
+	runBoardLoaders(t, testSAN)
+}
+
+func testSAN(t *testing.T, loader BoardLoader) {
+	assert := assert.New(t)
+
+	// Case: pieces, captures, check and mate
+	game, _ := testCaseGameWithBoard(loader)
+	assert.Equal("e4", game.SAN(LegalMove{From: E2, To: E4}))
+	assert.Equal("Nf3", game.SAN(LegalMove{From: G1, To: F3}))
+	assert.Equal("", game.SAN(LegalMove{From: E7, To: E5}))
+	assert.Equal("", game.SAN(LegalMove{From: E4, To: E5}))
+	game.Move(testPlayerWhite, E2, E4)
+	game.Move(testPlayerBlack, D7, D5)
+	assert.Equal("exd5", game.SAN(LegalMove{From: E4, To: D5}))
+	assert.Equal("Bb5+", game.SAN(LegalMove{From: F1, To: B5}))
+	game.Move(testPlayerWhite, E4, D5)
+	game.Move(testPlayerBlack, D8, D5)
+	game.Move(testPlayerWhite, B1, C3)
+	game.Move(testPlayerBlack, D5, E5)
+	game.Move(testPlayerWhite, F1, E2)
+	game.Move(testPlayerBlack, C8, G4)
+	game.Move(testPlayerWhite, D2, D4)
+	game.Move(testPlayerBlack, G4, E2)
+	game.Move(testPlayerWhite, G1, E2)
+	var sans []string
+	for _, m := range game.Movements() {
+		sans = append(sans, m.SAN)
+	}
+	assert.Equal([]string{"e4", "d5", "exd5", "Qxd5", "Nc3", "Qe5+", "Be2", "Bg4", "d4", "Bxe2", "Ngxe2"}, sans)
+
+	// Case: disambiguation
+	game, _ = ParseFENWithBoard("1k6/8/8/8/Q6Q/8/8/Q3K3 w - - 0 1", loader)
+	assert.Equal("Q1a3", game.SAN(LegalMove{From: A1, To: A3}))
+	assert.Equal("Qhe4", game.SAN(LegalMove{From: H4, To: E4}))
+	assert.Equal("Qa4d4", game.SAN(LegalMove{From: A4, To: D4}))
+	assert.Equal("Qb2+", game.SAN(LegalMove{From: A1, To: B2}))
+
+	// Case: castling, en passant and promotion
+	game, _ = ParseFENWithBoard("r3k3/1P6/8/3pP3/8/8/8/4K2R w Kq d6 0 1", loader)
+	assert.Equal("O-O", game.SAN(LegalMove{From: E1, To: G1}))
+	assert.Equal("exd6", game.SAN(LegalMove{From: E5, To: D6}))
+	assert.Equal("bxa8=Q+", game.SAN(LegalMove{From: B7, To: A8, Promotion: QueenIdentifier}))
+	assert.Equal("b8=N", game.SAN(LegalMove{From: B7, To: B8, Promotion: KnightIdentifier}))
+	game.Move(testPlayerWhite, E1, F1)
+	assert.Equal("O-O-O", game.SAN(LegalMove{From: E8, To: C8}))
+}
+
+func TestParseSAN(t *testing.T) {
+	assert := assert.New(t)
+
+	game, _ := ParseFEN("r3k3/1P6/8/3pP3/8/8/8/R3K2R w KQq d6 0 1")
+	for san, expected := range map[string]LegalMove{
+		"O-O":     {From: E1, To: G1},
+		"0-0-0":   {From: E1, To: C1},
+		"exd6":    {From: E5, To: D6},
+		"e6":      {From: E5, To: E6},
+		"bxa8=Q+": {From: B7, To: A8, Promotion: QueenIdentifier},
+		"b8N":     {From: B7, To: B8, Promotion: KnightIdentifier},
+		"Rb1":     {From: A1, To: B1},
+		"Rhf1":    {From: H1, To: F1},
+		"Rh1h8+!": {From: H1, To: H8},
+		"Kd2":     {From: E1, To: D2},
+	} {
+		m, err := game.ParseSAN(san)
+		assert.NoError(err, san)
+		assert.Equal(expected, m, san)
+	}
+
+	for san, msg := range map[string]string{
+		"b8":  "illegal SAN move \"b8\"",
+		"Nf3": "illegal SAN move \"Nf3\"",
+		"Ke3": "illegal SAN move \"Ke3\"",
+		"e9":  "invalid SAN move \"e9\"",
+		"Pe6": "invalid SAN move \"Pe6\"",
+		"":    "invalid SAN move \"\"",
+	} {
+		_, err := game.ParseSAN(san)
+		assert.EqualError(err, msg)
+	}
+
+	// Case: castling tokens resolve to castling only, not to other king movements
+	game, _ = ParseFEN("r3k3/8/8/8/8/8/8/5K1R b q - 0 1")
+	m, err := game.ParseSAN("O-O-O")
+	assert.NoError(err)
+	assert.Equal(LegalMove{From: E8, To: C8}, m)
+	_, err = game.ParseSAN("O-O")
+	assert.EqualError(err, "illegal SAN move \"O-O\"")
+	game.Move(game.Turn(), E8, D8)
+	_, err = game.ParseSAN("O-O")
+	assert.EqualError(err, "illegal SAN move \"O-O\"")
+	m, err = game.ParseSAN("Kg1")
+	assert.NoError(err)
+	assert.Equal(LegalMove{From: F1, To: G1}, m)
+
+	// Case: ambiguous
+	game, _ = ParseFEN("1k6/8/8/8/Q6Q/8/8/Q3K3 w - - 0 1")
+	_, err = game.ParseSAN("Qd4")
+	assert.EqualError(err, "ambiguous SAN move \"Qd4\"")
+	m, err = game.ParseSAN("Qa4d4")
+	assert.NoError(err)
+	assert.Equal(LegalMove{From: A4, To: D4}, m)
+}
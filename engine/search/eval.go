@@ -0,0 +1,138 @@
+package search
+
+import "github.com/dumbogo/chess/engine"
+
+// pieceValues material value in centipawns
+var pieceValues = [engine.KingIdentifier + 1]int{
+	engine.PawnIdentifier:   100,
+	engine.KnightIdentifier: 320,
+	engine.BishopIdentifier: 330,
+	engine.RookIdentifier:   500,
+	engine.QueenIdentifier:  900,
+}
+
+// pieceSquareTables bonus of a piece by square, from white point of view with the 8th rank first
+var pieceSquareTables = [engine.KingIdentifier + 1][64]int{
+	engine.PawnIdentifier: {
+		0, 0, 0, 0, 0, 0, 0, 0,
+		50, 50, 50, 50, 50, 50, 50, 50,
+		10, 10, 20, 30, 30, 20, 10, 10,
+		5, 5, 10, 25, 25, 10, 5, 5,
+		0, 0, 0, 20, 20, 0, 0, 0,
+		5, -5, -10, 0, 0, -10, -5, 5,
+		5, 10, 10, -20, -20, 10, 10, 5,
+		0, 0, 0, 0, 0, 0, 0, 0,
+	},
+	engine.KnightIdentifier: {
+		-50, -40, -30, -30, -30, -30, -40, -50,
+		-40, -20, 0, 0, 0, 0, -20, -40,
+		-30, 0, 10, 15, 15, 10, 0, -30,
+		-30, 5, 15, 20, 20, 15, 5, -30,
+		-30, 0, 15, 20, 20, 15, 0, -30,
+		-30, 5, 10, 15, 15, 10, 5, -30,
+		-40, -20, 0, 5, 5, 0, -20, -40,
+		-50, -40, -30, -30, -30, -30, -40, -50,
+	},
+	engine.BishopIdentifier: {
+		-20, -10, -10, -10, -10, -10, -10, -20,
+		-10, 0, 0, 0, 0, 0, 0, -10,
+		-10, 0, 5, 10, 10, 5, 0, -10,
+		-10, 5, 5, 10, 10, 5, 5, -10,
+		-10, 0, 10, 10, 10, 10, 0, -10,
+		-10, 10, 10, 10, 10, 10, 10, -10,
+		-10, 5, 0, 0, 0, 0, 5, -10,
+		-20, -10, -10, -10, -10, -10, -10, -20,
+	},
+	engine.RookIdentifier: {
+		0, 0, 0, 0, 0, 0, 0, 0,
+		5, 10, 10, 10, 10, 10, 10, 5,
+		-5, 0, 0, 0, 0, 0, 0, -5,
+		-5, 0, 0, 0, 0, 0, 0, -5,
+		-5, 0, 0, 0, 0, 0, 0, -5,
+		-5, 0, 0, 0, 0, 0, 0, -5,
+		-5, 0, 0, 0, 0, 0, 0, -5,
+		0, 0, 0, 5, 5, 0, 0, 0,
+	},
+	engine.QueenIdentifier: {
+		-20, -10, -10, -5, -5, -10, -10, -20,
+		-10, 0, 0, 0, 0, 0, 0, -10,
+		-10, 0, 5, 5, 5, 5, 0, -10,
+		-5, 0, 5, 5, 5, 5, 0, -5,
+		0, 0, 5, 5, 5, 5, 0, -5,
+		-10, 5, 5, 5, 5, 5, 0, -10,
+		-10, 0, 5, 0, 0, 0, 0, -10,
+		-20, -10, -10, -5, -5, -10, -10, -20,
+	},
+	engine.KingIdentifier: {
+		-30, -40, -40, -50, -50, -40, -40, -30,
+		-30, -40, -40, -50, -50, -40, -40, -30,
+		-30, -40, -40, -50, -50, -40, -40, -30,
+		-30, -40, -40, -50, -50, -40, -40, -30,
+		-20, -30, -30, -40, -40, -30, -30, -20,
+		-10, -20, -20, -20, -20, -20, -20, -10,
+		20, 20, 0, 0, 0, 0, 20, 20,
+		20, 30, 10, 0, 0, 10, 30, 20,
+	},
+}
+
+// kingEndgameTable king bonus by square once queens are gone or barely supported,
+// the king leaves the shelter and goes to the center
+var kingEndgameTable = [64]int{
+	-50, -40, -30, -20, -20, -30, -40, -50,
+	-30, -20, -10, 0, 0, -10, -20, -30,
+	-30, -10, 20, 30, 30, 20, -10, -30,
+	-30, -10, 30, 40, 40, 30, -10, -30,
+	-30, -10, 30, 40, 40, 30, -10, -30,
+	-30, -10, 20, 30, 30, 20, -10, -30,
+	-30, -30, 0, 0, 0, 0, -30, -30,
+	-50, -30, -30, -30, -30, -30, -30, -50,
+}
+
+// Evaluate returns the static evaluation of the game position in centipawns, material plus
+// piece-square tables, from the point of view of the player in turn
+func Evaluate(game engine.Game) int {
+	var score [engine.WhiteColor + 1]int
+	var queens, pieces [engine.WhiteColor + 1]int
+	var kings [engine.WhiteColor + 1]int
+	for loc, square := range game.Board().Squares() {
+		if square.Empty {
+			continue
+		}
+		color, id := square.Piece.Color(), square.Piece.Identifier()
+		switch id {
+		case engine.KingIdentifier:
+			kings[color] = tableIndex(loc, color)
+			continue
+		case engine.QueenIdentifier:
+			queens[color]++
+		case engine.RookIdentifier, engine.BishopIdentifier, engine.KnightIdentifier:
+			pieces[color]++
+		}
+		score[color] += pieceValues[id] + pieceSquareTables[id][tableIndex(loc, color)]
+	}
+	kingTable := &pieceSquareTables[engine.KingIdentifier]
+	if endgame(queens[engine.WhiteColor], pieces[engine.WhiteColor]) && endgame(queens[engine.BlackColor], pieces[engine.BlackColor]) {
+		kingTable = &kingEndgameTable
+	}
+	score[engine.WhiteColor] += kingTable[kings[engine.WhiteColor]]
+	score[engine.BlackColor] += kingTable[kings[engine.BlackColor]]
+
+	if game.Turn().Color == engine.WhiteColor {
+		return score[engine.WhiteColor] - score[engine.BlackColor]
+	}
+	return score[engine.BlackColor] - score[engine.WhiteColor]
+}
+
+// endgame returns true if a side has no queen, or a queen with at most one piece other than pawns
+func endgame(queens, pieces int) bool {
+	return queens == 0 || queens == 1 && pieces <= 1
+}
+
+// tableIndex returns the index of loc in piece-square tables of color
+func tableIndex(loc engine.SquareIdentifier, color engine.Color) int {
+	c := engine.SquareIdentifierToCoordinate(loc)
+	if color == engine.WhiteColor {
+		return int(engine.MAXY-c.Y)*8 + int(c.X)
+	}
+	return int(c.Y)*8 + int(c.X)
+}
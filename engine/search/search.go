@@ -0,0 +1,362 @@
+// Package search looks for the best move of a game position, using iterative deepening
+// alpha-beta search with quiescence search, move ordering and a transposition table
+package search
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/dumbogo/chess/engine"
+)
+
+// MateScore score of a checkmate, a mate found at ply n scores MateScore-n
+const MateScore = 100000
+
+// DefaultHashSize transposition table size in megabytes used by Search
+const DefaultHashSize = 16
+
+const (
+	infinity = MateScore + 1
+	// maxPly deepest ply searched, quiescence search and check extensions included
+	maxPly = 64
+	// checkInterval nodes searched between checks of the search limits
+	checkInterval = 1024
+)
+
+// ErrNoLegalMoves the player in turn has no legal moves to search
+var ErrNoLegalMoves = errors.New("no legal moves")
+
+// Limits bounds a search, zero values mean no limit.
+// A search without limits runs until the context is done
+type Limits struct {
+	// Depth plies searched
+	Depth int
+	// Time search duration
+	Time time.Duration
+	// Nodes positions searched
+	Nodes uint64
+}
+
+// Computer strength levels, each level searches one ply deeper for a longer time
+const (
+	DefaultLevel = 3
+	MaxLevel     = 8
+	timePerLevel = 250 * time.Millisecond
+)
+
+// LevelLimits returns the search limits of a computer strength level, from 1 to MaxLevel
+func LevelLimits(level int) Limits {
+	return Limits{
+		Depth: level,
+		Time:  time.Duration(level) * timePerLevel,
+	}
+}
+
+// Result best move found by the deepest search iteration completed
+type Result struct {
+	Move engine.LegalMove
+	// PV principal variation, the line expected to be played starting with Move
+	PV []engine.LegalMove
+	// Score centipawns from the point of view of the player in turn
+	Score   int
+	Depth   int
+	Nodes   uint64
+	Elapsed time.Duration
+}
+
+// Mate returns moves until checkmate, negative when the player in turn is the one checkmated,
+// 0 if no checkmate was found
+func (r Result) Mate() int {
+	switch {
+	case r.Score > MateScore-maxPly:
+		return (MateScore - r.Score + 1) / 2
+	case r.Score < -MateScore+maxPly:
+		return -(MateScore + r.Score + 1) / 2
+	}
+	return 0
+}
+
+// Searcher searches best moves, keeping the transposition table between searches
+type Searcher struct {
+	// OnIteration is called with the result of each iteration completed
+	OnIteration func(Result)
+
+	table *table
+
+	game     engine.Game
+	ctx      context.Context
+	limits   Limits
+	deadline time.Time
+	nodes    uint64
+	// stoppable false while the first iteration runs, so there is always a move to return
+	stoppable bool
+	stopped   bool
+	// hashes positions in the line being searched, for repetitions
+	hashes  []uint64
+	pv      [maxPly + 1][]engine.LegalMove
+	killers [maxPly + 1][2]engine.LegalMove
+	history [engine.H8 + 1][engine.H8 + 1]int
+}
+
+// New creates a Searcher with a transposition table of about hashSize megabytes
+func New(hashSize int) *Searcher {
+	return &Searcher{table: newTable(hashSize)}
+}
+
+// Search searches game with a new Searcher
+func Search(ctx context.Context, game engine.Game, limits Limits) (Result, error) {
+	return New(DefaultHashSize).Search(ctx, game, limits)
+}
+
+// Clear forgets positions searched before
+func (s *Searcher) Clear() {
+	s.table.clear()
+}
+
+// Search returns the best move for the player in turn. Moves are played and rolled back
+// in game during the search, game must not be used until Search returns
+func (s *Searcher) Search(ctx context.Context, game engine.Game, limits Limits) (Result, error) {
+	if status := game.Status(); status.Result != engine.OngoingResult {
+		return Result{}, fmt.Errorf("game finished, %s", status)
+	}
+	if len(game.LegalMoves()) == 0 {
+		return Result{}, ErrNoLegalMoves
+	}
+	start := time.Now()
+	s.game, s.ctx, s.limits = game, ctx, limits
+	s.deadline = time.Time{}
+	if limits.Time > 0 {
+		s.deadline = start.Add(limits.Time)
+	}
+	s.nodes, s.stoppable, s.stopped = 0, false, false
+	s.hashes = []uint64{game.Hash()}
+	s.killers = [maxPly + 1][2]engine.LegalMove{}
+	s.history = [engine.H8 + 1][engine.H8 + 1]int{}
+
+	maxDepth := limits.Depth
+	if maxDepth <= 0 || maxDepth > maxPly/2 {
+		maxDepth = maxPly / 2
+	}
+	var result Result
+	for depth := 1; depth <= maxDepth; depth++ {
+		score := s.negamax(depth, 0, -infinity, infinity)
+		if s.stopped {
+			break
+		}
+		s.stoppable = true
+		result = Result{
+			Move:    s.pv[0][0],
+			PV:      append([]engine.LegalMove{}, s.pv[0]...),
+			Score:   score,
+			Depth:   depth,
+			Nodes:   s.nodes,
+			Elapsed: time.Since(start),
+		}
+		if s.OnIteration != nil {
+			s.OnIteration(result)
+		}
+		// deeper iterations can not find a shorter mate, nor finish in the time left
+		if result.Mate() != 0 || limits.Time > 0 && result.Elapsed > limits.Time/2 {
+			break
+		}
+	}
+	result.Nodes, result.Elapsed = s.nodes, time.Since(start)
+	return result, nil
+}
+
+// negamax returns the score of the position searching depth plies, stores the best line in pv[ply]
+func (s *Searcher) negamax(depth, ply, alpha, beta int) int {
+	s.pv[ply] = s.pv[ply][:0]
+	if ply > 0 && s.repeated() {
+		return 0
+	}
+	if ply >= maxPly {
+		return Evaluate(s.game)
+	}
+	inCheck := s.inCheck()
+	if inCheck {
+		depth++
+	}
+	if depth <= 0 {
+		return s.quiescence(ply, alpha, beta)
+	}
+	if s.visit() {
+		return 0
+	}
+
+	hash := s.game.Hash()
+	var hashMove engine.LegalMove
+	if e, ok := s.table.probe(hash); ok {
+		hashMove = e.move
+		if score := scoreFromTable(int(e.score), ply); ply > 0 && int(e.depth) >= depth &&
+			(e.bound == exactBound || e.bound == lowerBound && score >= beta || e.bound == upperBound && score <= alpha) {
+			return score
+		}
+	}
+
+	moves := s.game.LegalMoves()
+	if len(moves) == 0 {
+		if inCheck {
+			return -MateScore + ply
+		}
+		return 0
+	}
+	s.order(moves, hashMove, ply)
+
+	best, b := -infinity, upperBound
+	var bestMove engine.LegalMove
+	for _, m := range moves {
+		s.play(m)
+		score := -s.negamax(depth-1, ply+1, -beta, -alpha)
+		s.rollback()
+		if s.stopped {
+			return 0
+		}
+		if score > best {
+			best, bestMove = score, m
+		}
+		if score > alpha {
+			alpha, b = score, exactBound
+			s.pv[ply] = append(append(s.pv[ply][:0], m), s.pv[ply+1]...)
+		}
+		if alpha >= beta {
+			b = lowerBound
+			if !s.capture(m) {
+				s.killers[ply][1], s.killers[ply][0] = s.killers[ply][0], m
+				s.history[m.From][m.To] += depth * depth
+			}
+			break
+		}
+	}
+	s.table.store(hash, depth, scoreToTable(best, ply), b, bestMove)
+	return best
+}
+
+// quiescence returns the score of the position once captures and promotions are played,
+// so positions are not evaluated in the middle of an exchange
+func (s *Searcher) quiescence(ply, alpha, beta int) int {
+	if s.visit() {
+		return 0
+	}
+	standPat := Evaluate(s.game)
+	if ply >= maxPly || standPat >= beta {
+		return standPat
+	}
+	if standPat > alpha {
+		alpha = standPat
+	}
+	var moves []engine.LegalMove
+	for _, m := range s.game.LegalMoves() {
+		if s.capture(m) || m.Promotion == engine.QueenIdentifier {
+			moves = append(moves, m)
+		}
+	}
+	s.order(moves, engine.LegalMove{}, ply)
+	for _, m := range moves {
+		s.play(m)
+		score := -s.quiescence(ply+1, -beta, -alpha)
+		s.rollback()
+		if s.stopped {
+			return 0
+		}
+		if score >= beta {
+			return score
+		}
+		if score > alpha {
+			alpha = score
+		}
+	}
+	return alpha
+}
+
+// visit counts a node searched, returns true if the search must stop
+func (s *Searcher) visit() bool {
+	s.nodes++
+	if s.stoppable && !s.stopped && s.nodes%checkInterval == 0 {
+		select {
+		case <-s.ctx.Done():
+			s.stopped = true
+		default:
+			s.stopped = !s.deadline.IsZero() && time.Now().After(s.deadline)
+		}
+	}
+	if s.stoppable && s.limits.Nodes > 0 && s.nodes >= s.limits.Nodes {
+		s.stopped = true
+	}
+	return s.stopped
+}
+
+func (s *Searcher) play(m engine.LegalMove) {
+	s.game.Play(m)
+	s.hashes = append(s.hashes, s.game.Hash())
+}
+
+func (s *Searcher) rollback() {
+	s.game.Rollback(1)
+	s.hashes = s.hashes[:len(s.hashes)-1]
+}
+
+// repeated returns true if the position was reached before in the line searched, scored as a draw
+func (s *Searcher) repeated() bool {
+	hash := s.hashes[len(s.hashes)-1]
+	for i := len(s.hashes) - 3; i >= 0; i -= 2 {
+		if s.hashes[i] == hash {
+			return true
+		}
+	}
+	return false
+}
+
+// inCheck returns true if the player in turn is in check
+func (s *Searcher) inCheck() bool {
+	oponent := engine.Player{Color: engine.WhiteColor}
+	if s.game.Turn().Color == engine.WhiteColor {
+		oponent.Color = engine.BlackColor
+	}
+	return s.game.IsCheckBy(oponent)
+}
+
+// capture returns true if m captures a piece, en passant included
+func (s *Searcher) capture(m engine.LegalMove) bool {
+	squares := s.game.Board().Squares()
+	if !squares[m.To].Empty {
+		return true
+	}
+	from, to := engine.SquareIdentifierToCoordinate(m.From), engine.SquareIdentifierToCoordinate(m.To)
+	return squares[m.From].Piece.Identifier() == engine.PawnIdentifier && from.X != to.X
+}
+
+// order sorts moves searching first the hash move, then captures by most valuable victim and least
+// valuable attacker, promotions, killer moves and the remaining moves by history
+func (s *Searcher) order(moves []engine.LegalMove, hashMove engine.LegalMove, ply int) {
+	squares := s.game.Board().Squares()
+	scores := make(map[engine.LegalMove]int, len(moves))
+	for _, m := range moves {
+		var score int
+		switch {
+		case m == hashMove:
+			score = 1 << 30
+		case s.capture(m):
+			victim := engine.PawnIdentifier
+			if !squares[m.To].Empty {
+				victim = squares[m.To].Piece.Identifier()
+			}
+			score = 1<<28 + pieceValues[victim]*16 - int(squares[m.From].Piece.Identifier())
+		case m.Promotion != 0:
+			score = 1<<27 + pieceValues[m.Promotion]
+		case m == s.killers[ply][0]:
+			score = 1<<26 + 1
+		case m == s.killers[ply][1]:
+			score = 1 << 26
+		default:
+			score = s.history[m.From][m.To]
+		}
+		scores[m] = score
+	}
+	sort.SliceStable(moves, func(i, j int) bool {
+		return scores[moves[i]] > scores[moves[j]]
+	})
+}
@@ -0,0 +1,116 @@
+package search
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dumbogo/chess/engine"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluate(t *testing.T) {
+	assert := assert.New(t)
+	testCases := []struct {
+		fen      string
+		mirrored string
+	}{
+		{engine.StartingFEN, engine.StartingFEN},
+		{
+			"r1bqkbnr/pppp1ppp/2n5/4p3/4P3/5N2/PPPP1PPP/RNBQKB1R w KQkq - 2 3",
+			"rnbqkb1r/pppp1ppp/5n2/4p3/4P3/2N5/PPPP1PPP/R1BQKBNR b KQkq - 2 3",
+		},
+		{"4k3/8/8/8/8/8/3Q4/4K3 w - - 0 1", "4k3/3q4/8/8/8/8/8/4K3 b - - 0 1"},
+	}
+	for _, tc := range testCases {
+		game, err := engine.ParseFENWithBoard(tc.fen, engine.LoadBitboard)
+		assert.NoError(err)
+		mirrored, err := engine.ParseFENWithBoard(tc.mirrored, engine.LoadBitboard)
+		assert.NoError(err)
+		assert.Equal(Evaluate(game), Evaluate(mirrored), tc.fen)
+	}
+
+	game, _ := engine.ParseFENWithBoard(engine.StartingFEN, engine.LoadBitboard)
+	assert.Equal(0, Evaluate(game))
+	game, _ = engine.ParseFENWithBoard("4k3/8/8/8/8/8/3Q4/4K3 w - - 0 1", engine.LoadBitboard)
+	assert.Greater(Evaluate(game), 800)
+	game, _ = engine.ParseFENWithBoard("4k3/8/8/8/8/8/3Q4/4K3 b - - 0 1", engine.LoadBitboard)
+	assert.Less(Evaluate(game), -800)
+}
+
+func TestSearch(t *testing.T) {
+	assert := assert.New(t)
+	testCases := []struct {
+		name  string
+		fen   string
+		depth int
+		move  engine.LegalMove
+		mate  int
+	}{
+		{"mate in one", "6k1/5ppp/8/8/8/8/8/R5K1 w - - 0 1", 2, engine.LegalMove{From: engine.A1, To: engine.A8}, 1},
+		{"mate in two", "r2qkb1r/pp2nppp/3p4/2pNN1B1/2BnP3/3P4/PPP2PPP/R2bK2R w KQkq - 1 10", 4, engine.LegalMove{From: engine.D5, To: engine.F6}, 2},
+		{"mated in one", "6k1/8/8/8/8/1r6/r7/7K w - - 0 1", 3, engine.LegalMove{From: engine.H1, To: engine.G1}, -1},
+		{"hanging queen", "4k3/8/8/3q4/8/8/3R4/4K3 w - - 0 1", 3, engine.LegalMove{From: engine.D2, To: engine.D5}, 0},
+		{"promotion", "8/4P2r/8/8/8/k7/8/4K3 w - - 0 1", 3, engine.LegalMove{From: engine.E7, To: engine.E8, Promotion: engine.QueenIdentifier}, 0},
+	}
+	for _, tc := range testCases {
+		game, err := engine.ParseFENWithBoard(tc.fen, engine.LoadBitboard)
+		assert.NoError(err)
+		var iterations []Result
+		searcher := New(1)
+		searcher.OnIteration = func(r Result) { iterations = append(iterations, r) }
+		result, err := searcher.Search(context.Background(), game, Limits{Depth: tc.depth})
+		assert.NoError(err, tc.name)
+		assert.Equal(tc.move, result.Move, tc.name)
+		assert.Equal(tc.mate, result.Mate(), tc.name)
+		assert.NotEmpty(iterations, tc.name)
+		assert.LessOrEqual(result.Depth, tc.depth, tc.name)
+		assert.NotZero(result.Nodes, tc.name)
+
+		// Case: game restored and principal variation made of legal moves
+		assert.Equal(tc.fen, game.FEN(), tc.name)
+		assert.Equal(result.Move, result.PV[0], tc.name)
+		for _, m := range result.PV {
+			assert.True(game.Play(m), tc.name)
+		}
+	}
+
+	// Case: game finished
+	game, _ := engine.ParseFENWithBoard("R5k1/5ppp/8/8/8/8/8/6K1 b - - 1 1", engine.LoadBitboard)
+	_, err := Search(context.Background(), game, Limits{Depth: 1})
+	assert.Error(err)
+}
+
+func TestSearchLimits(t *testing.T) {
+	assert := assert.New(t)
+	fen := "r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1"
+
+	game, _ := engine.ParseFENWithBoard(fen, engine.LoadBitboard)
+	start := time.Now()
+	result, err := Search(context.Background(), game, Limits{Time: 200 * time.Millisecond})
+	assert.NoError(err)
+	assert.Less(int64(time.Since(start)), int64(time.Second))
+	assert.NotZero(result.Depth)
+	assert.Contains(game.LegalMoves(), result.Move)
+	assert.Equal(fen, game.FEN())
+
+	result, err = Search(context.Background(), game, Limits{Nodes: 2000})
+	assert.NoError(err)
+	assert.Contains(game.LegalMoves(), result.Move)
+	assert.Equal(fen, game.FEN())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start = time.Now()
+	result, err = Search(ctx, game, Limits{})
+	assert.NoError(err)
+	assert.Less(int64(time.Since(start)), int64(time.Second))
+	assert.Contains(game.LegalMoves(), result.Move)
+	assert.Equal(fen, game.FEN())
+}
+
+func TestLevelLimits(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal(Limits{Depth: 1, Time: 250 * time.Millisecond}, LevelLimits(1))
+	assert.Equal(Limits{Depth: MaxLevel, Time: 2 * time.Second}, LevelLimits(MaxLevel))
+}
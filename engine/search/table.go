@@ -0,0 +1,79 @@
+package search
+
+import "github.com/dumbogo/chess/engine"
+
+// bound kind of score stored in the transposition table
+type bound uint8
+
+const (
+	_ bound = iota
+	// exactBound score is the position value
+	exactBound
+	// lowerBound score caused a beta cutoff, the position value is at least score
+	lowerBound
+	// upperBound no move raised alpha, the position value is at most score
+	upperBound
+)
+
+// entrySize approximated size in bytes of an entry
+const entrySize = 24
+
+// entry transposition table entry
+type entry struct {
+	hash  uint64
+	move  engine.LegalMove
+	score int32
+	depth int8
+	bound bound
+}
+
+// table transposition table indexed by Zobrist hash, entries are always replaced
+type table struct {
+	entries []entry
+}
+
+// newTable creates a table using about sizeMB megabytes
+func newTable(sizeMB int) *table {
+	size := sizeMB * 1024 * 1024 / entrySize
+	if size < 1 {
+		size = 1
+	}
+	return &table{entries: make([]entry, size)}
+}
+
+func (t *table) probe(hash uint64) (entry, bool) {
+	e := t.entries[hash%uint64(len(t.entries))]
+	return e, e.bound != 0 && e.hash == hash
+}
+
+func (t *table) store(hash uint64, depth int, score int, b bound, move engine.LegalMove) {
+	t.entries[hash%uint64(len(t.entries))] = entry{hash: hash, move: move, score: int32(score), depth: int8(depth), bound: b}
+}
+
+func (t *table) clear() {
+	for i := range t.entries {
+		t.entries[i] = entry{}
+	}
+}
+
+// scoreToTable returns score relative to the position stored, mate scores are kept as distance from the position
+func scoreToTable(score, ply int) int {
+	switch {
+	case score > MateScore-maxPly:
+		return score + ply
+	case score < -MateScore+maxPly:
+		return score - ply
+	}
+	return score
+}
+
+// scoreFromTable returns score relative to the search root of a score stored at ply
+func scoreFromTable(score, ply int) int {
+	switch {
+	case score > MateScore-maxPly:
+		return score - ply
+	case score < -MateScore+maxPly:
+		return score + ply
+	}
+	return score
+}
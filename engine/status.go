@@ -0,0 +1,181 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Result game result
+type Result uint8
+
+// Results
+const (
+	_ Result = iota
+	OngoingResult
+	WhiteWinsResult
+	BlackWinsResult
+	DrawResult
+)
+
+func (r Result) String() string {
+	switch r {
+	case OngoingResult:
+		return "ongoing"
+	case WhiteWinsResult:
+		return "white wins"
+	case BlackWinsResult:
+		return "black wins"
+	case DrawResult:
+		return "draw"
+	default:
+		return "unknown"
+	}
+}
+
+// Reason why a game finished
+type Reason uint8
+
+// Reasons
+const (
+	_ Reason = iota
+	CheckmateReason
+	StalemateReason
+	FiftyMoveReason
+	RepetitionReason
+	InsufficientMaterialReason
+	ResignationReason
+	TimeoutReason
+	AgreementReason
+	AbandonmentReason
+)
+
+func (r Reason) String() string {
+	switch r {
+	case CheckmateReason:
+		return "checkmate"
+	case StalemateReason:
+		return "stalemate"
+	case FiftyMoveReason:
+		return "fifty-move rule"
+	case RepetitionReason:
+		return "threefold repetition"
+	case InsufficientMaterialReason:
+		return "insufficient material"
+	case ResignationReason:
+		return "resignation"
+	case TimeoutReason:
+		return "timeout"
+	case AgreementReason:
+		return "agreement"
+	case AbandonmentReason:
+		return "abandonment"
+	default:
+		return ""
+	}
+}
+
+// Status game status, Reason is only set when the game finished
+type Status struct {
+	Result Result
+	Reason Reason
+}
+
+func (s Status) String() string {
+	if s.Result == OngoingResult {
+		return s.Result.String()
+	}
+	return fmt.Sprintf("%s by %s", s.Result, s.Reason)
+}
+
+// fiftyMoveHalfmoves halfmoves without pawn movements or captures to claim a draw
+const fiftyMoveHalfmoves = 100
+
+func (g *game) Status() Status {
+	if g.finished != nil {
+		return *g.finished
+	}
+	// checkmate prevails over the draws, i.e. mate given on the fiftieth move
+	if !g.hasLegalMove(g.turn) {
+		if !g.IsCheckBy(g.oponentTurn()) {
+			return Status{Result: DrawResult, Reason: StalemateReason}
+		}
+		if g.turn.Color == WhiteColor {
+			return Status{Result: BlackWinsResult, Reason: CheckmateReason}
+		}
+		return Status{Result: WhiteWinsResult, Reason: CheckmateReason}
+	}
+	if g.halfmoveClock() >= fiftyMoveHalfmoves {
+		return Status{Result: DrawResult, Reason: FiftyMoveReason}
+	}
+	if g.insufficientMaterial() {
+		return Status{Result: DrawResult, Reason: InsufficientMaterialReason}
+	}
+	if g.repetitions() >= 3 {
+		return Status{Result: DrawResult, Reason: RepetitionReason}
+	}
+	return Status{Result: OngoingResult}
+}
+
+func (g *game) Finish(result Result, reason Reason) error {
+	if status := g.Status(); status.Result != OngoingResult {
+		return fmt.Errorf("game already finished, %s", status)
+	}
+	if result == OngoingResult || reason == 0 {
+		return errors.New("must define result and reason to finish the game")
+	}
+	g.finished = &Status{Result: result, Reason: reason}
+	return nil
+}
+
+// halfmoveClock returns number of halfmoves since last pawn movement or capture
+func (g *game) halfmoveClock() int {
+	for i := len(g.movements) - 1; i >= 0; i-- {
+		m := g.movements[i]
+		if m.PieceMoved.Identifier() == PawnIdentifier || m.PieceEaten != nil {
+			return len(g.movements) - 1 - i
+		}
+	}
+	return g.initialHalfmoveClock + len(g.movements)
+}
+
+// insufficientMaterial returns true if neither player can checkmate:
+// only kings left, a single minor piece, or bishops all on the same square color
+func (g *game) insufficientMaterial() bool {
+	var pieces []Square
+	for _, square := range g.board.Squares() {
+		if !square.Empty && square.Piece.Identifier() != KingIdentifier {
+			pieces = append(pieces, square)
+		}
+	}
+	if len(pieces) == 0 {
+		return true
+	}
+	if len(pieces) == 1 {
+		id := pieces[0].Piece.Identifier()
+		return id == BishopIdentifier || id == KnightIdentifier
+	}
+	squareColor := (pieces[0].Coordinates.X + pieces[0].Coordinates.Y) % 2
+	for _, square := range pieces {
+		if square.Piece.Identifier() != BishopIdentifier ||
+			(square.Coordinates.X+square.Coordinates.Y)%2 != squareColor {
+			return false
+		}
+	}
+	return true
+}
+
+// repetitions returns how many times the current position was reached.
+// Positions only repeat since the last pawn movement, capture or castling
+func (g *game) repetitions() int {
+	count := 1
+	for i := len(g.movements) - 1; i >= 0; i-- {
+		m := g.movements[i]
+		if m.PieceMoved.Identifier() == PawnIdentifier || m.PieceEaten != nil || m.Castling {
+			break
+		}
+		if g.hashes[i] == g.hash {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,131 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testCaseKingsOnlyGame returns a game with only both kings on the board
+func testCaseKingsOnlyGame() Game {
+	game, _ := testCaseGameGenerate()
+	for loc := A1; loc <= H8; loc++ {
+		square := game.Board().Squares()[loc]
+		if !square.Empty && square.Piece.Identifier() != KingIdentifier {
+			game.Board().EatPiece(loc)
+		}
+	}
+	return game
+}
+
+func TestStatus(t *testing.T) {
+	runBoardLoaders(t, testStatus)
+}
+
+func testStatus(t *testing.T, loader BoardLoader) {
+	assert := assert.New(t)
+
+	// Case: ongoing
+	game, _ := testCaseGameWithBoard(loader)
+	assert.Equal(Status{Result: OngoingResult}, game.Status())
+
+	// Case: checkmate
+	game.Move(testPlayerWhite, F2, F3)
+	game.Move(testPlayerBlack, E7, E5)
+	game.Move(testPlayerWhite, G2, G4)
+	game.Move(testPlayerBlack, D8, H4)
+	assert.Equal(Status{Result: BlackWinsResult, Reason: CheckmateReason}, game.Status())
+	ok, err := game.Move(testPlayerWhite, A2, A3)
+	assert.False(ok)
+	assert.EqualError(err, "checkmate, winner is black")
+
+	// Case: stalemate
+	game = testCaseKingsOnlyGame()
+	game.Board().EatPiece(E8)
+	game.Board().FillSquare(A8, NewKing(BlackColor))
+	game.Board().FillSquare(B6, NewQueen(WhiteColor))
+	game.Move(testPlayerWhite, E1, D1)
+	assert.Equal(Status{Result: DrawResult, Reason: StalemateReason}, game.Status())
+
+	// Case: insufficient material
+	game = testCaseKingsOnlyGame()
+	assert.Equal(Status{Result: DrawResult, Reason: InsufficientMaterialReason}, game.Status())
+	game.Board().FillSquare(C1, NewBishop(WhiteColor))
+	game.Board().FillSquare(F8, NewBishop(BlackColor))
+	assert.Equal(Status{Result: DrawResult, Reason: InsufficientMaterialReason}, game.Status())
+	game.Board().FillSquare(C8, NewBishop(BlackColor))
+	assert.Equal(Status{Result: OngoingResult}, game.Status())
+
+	// Case: threefold repetition
+	game, _ = testCaseGameWithBoard(loader)
+	for i := 0; i < 2; i++ {
+		game.Move(testPlayerWhite, G1, F3)
+		game.Move(testPlayerBlack, G8, F6)
+		game.Move(testPlayerWhite, F3, G1)
+		game.Move(testPlayerBlack, F6, G8)
+	}
+	assert.Equal(Status{Result: DrawResult, Reason: RepetitionReason}, game.Status())
+	assert.Len(game.Movements(), 8)
+	ok, err = game.Move(testPlayerWhite, G1, F3)
+	assert.False(ok)
+	assert.Error(err)
+
+	// Case: fifty-move rule
+	movements := []Movement{}
+	for i := 0; i < 25; i++ {
+		movements = append(movements,
+			Movement{Player: testPlayerWhite, PieceMoved: NewKnight(WhiteColor), From: G1, To: F3},
+			Movement{Player: testPlayerBlack, PieceMoved: NewKnight(BlackColor), From: G8, To: F6},
+			Movement{Player: testPlayerWhite, PieceMoved: NewKnight(WhiteColor), From: F3, To: G1},
+			Movement{Player: testPlayerBlack, PieceMoved: NewKnight(BlackColor), From: F6, To: G8},
+		)
+	}
+	game, _ = LoadGame(
+		"fifty",
+		loader(&testPlayerWhite, &testPlayerBlack, PristineSquares()),
+		testPlayerWhite,
+		testPlayerWhite,
+		testPlayerBlack,
+		PiecesList{},
+		PiecesList{},
+		movements,
+	)
+	assert.Equal(Status{Result: DrawResult, Reason: FiftyMoveReason}, game.Status())
+
+	// Case: checkmate on the hundredth halfmove prevails over the fifty-move rule, 7k/8/6K1/8/8/8/8/R7 w - - 99 80 after Ra8#
+	board := loader(&testPlayerWhite, &testPlayerBlack, PristineSquares())
+	for loc := A1; loc <= H8; loc++ {
+		if !board.Squares()[loc].Empty {
+			board.EatPiece(loc)
+		}
+	}
+	board.FillSquare(H8, NewKing(BlackColor))
+	board.FillSquare(G6, NewKing(WhiteColor))
+	board.FillSquare(A8, NewRook(WhiteColor))
+	game, _ = LoadGame(
+		"fifty mate",
+		board,
+		testPlayerBlack,
+		testPlayerWhite,
+		testPlayerBlack,
+		PiecesList{KingIdentifier: 1, RookIdentifier: 1},
+		PiecesList{KingIdentifier: 1},
+		movements,
+	)
+	assert.Equal(Status{Result: WhiteWinsResult, Reason: CheckmateReason}, game.Status())
+}
+
+func TestFinish(t *testing.T) {
+	assert := assert.New(t)
+
+	game, _ := testCaseGameGenerate()
+	assert.Error(game.Finish(OngoingResult, ResignationReason))
+	assert.Nil(game.Finish(BlackWinsResult, ResignationReason))
+	assert.Equal(Status{Result: BlackWinsResult, Reason: ResignationReason}, game.Status())
+	assert.Equal("black wins by resignation", game.Status().String())
+	assert.Error(game.Finish(DrawResult, AgreementReason))
+
+	ok, err := game.Move(testPlayerWhite, A2, A3)
+	assert.False(ok)
+	assert.EqualError(err, "game finished, black wins by resignation")
+}
@@ -0,0 +1,116 @@
+package engine
+
+import "math/bits"
+
+// zobristSeed seed of Zobrist keys, keys must not change as hashes may be stored
+const zobristSeed = 0x9E3779B97F4A7C15
+
+var (
+	zobristPieces    [WhiteColor + 1][KingIdentifier + 1][64]uint64
+	zobristBlackTurn uint64
+	// zobristCastling keys by castling right flag bit
+	zobristCastling  [4]uint64
+	zobristEnPassant [MAXX + 1]uint64
+)
+
+func init() {
+	state := uint64(zobristSeed)
+	// next returns a splitmix64 pseudo random number
+	next := func() uint64 {
+		state += 0x9E3779B97F4A7C15
+		z := state
+		z = (z ^ (z >> 30)) * 0xBF58476D1CE4E5B9
+		z = (z ^ (z >> 27)) * 0x94D049BB133111EB
+		return z ^ (z >> 31)
+	}
+	for _, color := range []Color{WhiteColor, BlackColor} {
+		for i := PawnIdentifier; i <= KingIdentifier; i++ {
+			for sq := range zobristPieces[color][i] {
+				zobristPieces[color][i][sq] = next()
+			}
+		}
+	}
+	zobristBlackTurn = next()
+	for i := range zobristCastling {
+		zobristCastling[i] = next()
+	}
+	for i := range zobristEnPassant {
+		zobristEnPassant[i] = next()
+	}
+}
+
+func (g *game) Hash() uint64 {
+	return g.hash
+}
+
+// zobristPiece returns the key of piece placed in loc
+func zobristPiece(loc SquareIdentifier, piece Piece) uint64 {
+	return zobristPieces[piece.Color()][piece.Identifier()][loc-1]
+}
+
+// zobrist returns the hash of the current position computed from scratch
+func (g *game) zobrist() uint64 {
+	var hash uint64
+	for loc, square := range g.board.Squares() {
+		if !square.Empty {
+			hash ^= zobristPiece(loc, square.Piece)
+		}
+	}
+	if g.turn.Color == BlackColor {
+		hash ^= zobristBlackTurn
+	}
+	return hash ^ g.zobristState(len(g.movements))
+}
+
+// zobristState returns the hash of castling rights and en passant file after the first n movements
+func (g *game) zobristState(n int) uint64 {
+	var hash uint64
+	for _, c := range castlings {
+		if g.castlingAvailableAfter(c, n) {
+			hash ^= zobristCastling[bits.TrailingZeros8(c.right)]
+		}
+	}
+	if n == 0 && g.enPassant != 0 {
+		hash ^= zobristEnPassant[SquareIdentifierToCoordinate(g.enPassant).X]
+	} else if n > 0 {
+		m := g.movements[n-1]
+		from, to := SquareIdentifierToCoordinate(m.From), SquareIdentifierToCoordinate(m.To)
+		if m.PieceMoved.Identifier() == PawnIdentifier && (int(from.Y)-int(to.Y) == 2 || int(to.Y)-int(from.Y) == 2) {
+			hash ^= zobristEnPassant[from.X]
+		}
+	}
+	return hash
+}
+
+// zobristMovement returns the hash difference between the positions before and after movement n,
+// applied both when the movement is made and when it is rolled back
+func (g *game) zobristMovement(n int) uint64 {
+	m := g.movements[n]
+	hash := zobristBlackTurn ^ g.zobristState(n) ^ g.zobristState(n+1) ^ zobristPiece(m.From, m.PieceMoved)
+	if m.Promotion != nil {
+		hash ^= zobristPiece(m.To, m.Promotion)
+	} else {
+		hash ^= zobristPiece(m.To, m.PieceMoved)
+	}
+	if m.EnPassant {
+		hash ^= zobristPiece(enPassantSquare(m.From, m.To), m.PieceEaten)
+	} else if m.PieceEaten != nil {
+		hash ^= zobristPiece(m.To, m.PieceEaten)
+	}
+	if m.Castling {
+		rooks := zobristPieces[m.PieceMoved.Color()][RookIdentifier]
+		hash ^= rooks[m.RookFrom-1] ^ rooks[m.RookTo-1]
+	}
+	return hash
+}
+
+// initHash computes the hash of the current position and of the positions before each movement
+func (g *game) initHash() {
+	g.hash = g.zobrist()
+	g.hashes = make([]uint64, len(g.movements))
+	hash := g.hash
+	for n := len(g.movements) - 1; n >= 0; n-- {
+		hash ^= g.zobristMovement(n)
+		g.hashes[n] = hash
+	}
+}
@@ -0,0 +1,69 @@
+package engine
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHash(t *testing.T) {
+	assert := assert.New(t)
+
+	g, _ := testCaseGameGenerate()
+	initial := g.Hash()
+	fenGame, _ := ParseFEN(StartingFEN)
+	assert.Equal(initial, fenGame.Hash())
+	assert.NotZero(initial)
+
+	// Case: transpositions reach the same hash
+	g.Move(testPlayerWhite, G1, F3)
+	g.Move(testPlayerBlack, G8, F6)
+	g.Move(testPlayerWhite, B1, C3)
+	transposed, _ := testCaseGameGenerate()
+	transposed.Move(testPlayerWhite, B1, C3)
+	transposed.Move(testPlayerBlack, G8, F6)
+	transposed.Move(testPlayerWhite, G1, F3)
+	assert.Equal(g.Hash(), transposed.Hash())
+
+	// Case: player in turn, en passant and castling rights are part of the position
+	g.Rollback(3)
+	assert.Equal(initial, g.Hash())
+	g.Move(testPlayerWhite, E2, E4)
+	enPassant, _ := ParseFEN("rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq e3 0 1")
+	noEnPassant, _ := ParseFEN("rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq - 0 1")
+	whiteTurn, _ := ParseFEN("rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR w KQkq - 0 1")
+	noCastling, _ := ParseFEN("rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b Qkq e3 0 1")
+	assert.Equal(enPassant.Hash(), g.Hash())
+	assert.NotEqual(noEnPassant.Hash(), g.Hash())
+	assert.NotEqual(whiteTurn.Hash(), noEnPassant.Hash())
+	assert.NotEqual(noCastling.Hash(), g.Hash())
+
+	// Case: incremental updates equal hashes computed from scratch
+	random := rand.New(rand.NewSource(1))
+	for _, fen := range []string{
+		StartingFEN,
+		"r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1",
+		"r3k2r/Pppp1ppp/1b3nbN/nP6/BBP1P3/q4N2/Pp1P2PP/R2Q1RK1 w kq - 0 1",
+	} {
+		g, _ := ParseFEN(fen)
+		hashes := []uint64{g.Hash()}
+		for ply := 0; ply < 80; ply++ {
+			moves := g.LegalMoves()
+			if len(moves) == 0 {
+				break
+			}
+			m := moves[random.Intn(len(moves))]
+			g.Move(g.Turn(), m.From, m.To, m.Promotion)
+			fenGame, _ := ParseFEN(g.FEN())
+			assert.Equal(fenGame.Hash(), g.Hash(), g.FEN())
+			assert.Equal(g.(*game).zobrist(), g.Hash())
+			hashes = append(hashes, g.Hash())
+		}
+		for len(hashes) > 1 {
+			hashes = hashes[:len(hashes)-1]
+			g.Rollback(1)
+			assert.Equal(hashes[len(hashes)-1], g.Hash())
+		}
+	}
+}
@@ -0,0 +1,260 @@
+// Package local plays games in the terminal using only the engine, without the chess server
+package local
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dumbogo/chess/engine"
+	"github.com/dumbogo/chess/engine/search"
+	"github.com/dumbogo/chess/uci"
+)
+
+const help = `Commands:
+  <move>        play a move in SAN, i.e. "Nf3", or long algebraic notation, i.e. "g1f3"
+  moves         list legal moves
+  undo          take back the last move, the computer one included
+  save <file>   save the game, in PGN if file ends with .pgn, in FEN otherwise
+  load <file>   load a game saved in PGN or FEN
+  help          print this help
+  quit          end the session`
+
+// Options local game options
+type Options struct {
+	// Computer color played by the built-in engine, 0 for games between two humans
+	Computer engine.Color
+	// Level computer strength from 1 to search.MaxLevel
+	Level int
+	// Board options the board is drawn with, flipped again when the computer plays white
+	Board engine.RenderOptions
+}
+
+// Session a game played in the terminal, reading moves and commands line by line
+type Session struct {
+	game     engine.Game
+	options  Options
+	searcher *search.Searcher
+	in       *bufio.Scanner
+	out      io.Writer
+}
+
+// NewGame returns a game from the starting position, players named after options
+func NewGame(options Options) engine.Game {
+	white := engine.Player{Name: "White", Color: engine.WhiteColor}
+	black := engine.Player{Name: "Black", Color: engine.BlackColor}
+	switch options.Computer {
+	case engine.WhiteColor:
+		white.Name = "Computer"
+	case engine.BlackColor:
+		black.Name = "Computer"
+	}
+	game, _ := engine.NewGameWithBoard("Local game", black, white, engine.LoadBitboard)
+	return game
+}
+
+// NewSession creates a Session playing game, reading from in and writing to out
+func NewSession(game engine.Game, options Options, in io.Reader, out io.Writer) *Session {
+	if options.Level <= 0 {
+		options.Level = search.DefaultLevel
+	}
+	if options.Level > search.MaxLevel {
+		options.Level = search.MaxLevel
+	}
+	return &Session{
+		game:     game,
+		options:  options,
+		searcher: search.New(search.DefaultHashSize),
+		in:       bufio.NewScanner(in),
+		out:      out,
+	}
+}
+
+// Run plays until quit or the end of the input
+func (s *Session) Run() error {
+	s.computerMove()
+	s.draw()
+	for {
+		s.prompt()
+		if !s.in.Scan() {
+			return s.in.Err()
+		}
+		fields := strings.Fields(s.in.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		var err error
+		switch command, args := fields[0], fields[1:]; command {
+		case "quit", "exit":
+			return nil
+		case "help":
+			fmt.Fprintln(s.out, help)
+			continue
+		case "moves":
+			s.printMoves()
+			continue
+		case "undo":
+			err = s.undo()
+		case "save":
+			if len(args) != 1 {
+				err = errors.New("usage: save <file>")
+				break
+			}
+			if err = Save(s.game, args[0]); err == nil {
+				fmt.Fprintf(s.out, "Saved %s\n", args[0])
+				continue
+			}
+		case "load":
+			if len(args) != 1 {
+				err = errors.New("usage: load <file>")
+				break
+			}
+			var game engine.Game
+			if game, err = Load(args[0]); err == nil {
+				s.game = game
+				s.searcher.Clear()
+				s.computerMove()
+			}
+		default:
+			err = s.move(command)
+		}
+		if err != nil {
+			fmt.Fprintf(s.out, "Error: %v\n", err)
+			continue
+		}
+		s.draw()
+	}
+}
+
+// move plays the move of the player in turn and the computer reply
+func (s *Session) move(input string) error {
+	if status := s.game.Status(); status.Result != engine.OngoingResult {
+		return fmt.Errorf("game finished, %s", status)
+	}
+	m, err := s.game.ParseSAN(input)
+	if err != nil {
+		var lanErr error
+		if m, lanErr = uci.ParseMove(s.game, input); lanErr != nil {
+			return err
+		}
+	}
+	if ok, err := s.game.Move(s.game.Turn(), m.From, m.To, m.Promotion); !ok {
+		return err
+	}
+	s.computerMove()
+	return nil
+}
+
+// computerMove plays the computer move when it is its turn
+func (s *Session) computerMove() {
+	if s.game.Turn().Color != s.options.Computer || s.game.Status().Result != engine.OngoingResult {
+		return
+	}
+	result, err := s.searcher.Search(context.Background(), s.game, search.LevelLimits(s.options.Level))
+	if err != nil {
+		fmt.Fprintf(s.out, "Error: %v\n", err)
+		return
+	}
+	s.game.Move(s.game.Turn(), result.Move.From, result.Move.To, result.Move.Promotion)
+}
+
+// undo takes back the last move, and the computer move before it so the human is in turn again
+func (s *Session) undo() error {
+	n := 1
+	if s.options.Computer != 0 && s.game.Turn().Color != s.options.Computer {
+		n = 2
+	}
+	movements := len(s.game.Movements())
+	if movements == 0 {
+		return errors.New("no moves to undo")
+	}
+	if n > movements {
+		n = movements
+	}
+	s.game.Rollback(n)
+	s.computerMove()
+	return nil
+}
+
+func (s *Session) draw() {
+	board := s.options.Board
+	if s.options.Computer == engine.WhiteColor {
+		board.Flip = !board.Flip
+	}
+	fmt.Fprint(s.out, s.game.Render(board))
+	if movements := s.game.Movements(); len(movements) > 0 {
+		last := movements[len(movements)-1]
+		fmt.Fprintf(s.out, "Last move: %s\n", last.SAN)
+	}
+	status := s.game.Status()
+	switch {
+	case status.Result != engine.OngoingResult:
+		fmt.Fprintf(s.out, "Game finished, %s\n", status)
+	case s.game.IsCheckBy(oponent(s.game.Turn().Color)):
+		fmt.Fprintln(s.out, "Check")
+	}
+}
+
+func (s *Session) prompt() {
+	color := "white"
+	if s.game.Turn().Color == engine.BlackColor {
+		color = "black"
+	}
+	fmt.Fprintf(s.out, "%s> ", color)
+}
+
+func (s *Session) printMoves() {
+	moves := s.game.LegalMoves()
+	sans := make([]string, len(moves))
+	for i, m := range moves {
+		sans[i] = s.game.SAN(m)
+	}
+	fmt.Fprintln(s.out, strings.Join(sans, " "))
+}
+
+// oponent returns the player of the other color
+func oponent(color engine.Color) engine.Player {
+	if color == engine.WhiteColor {
+		return engine.Player{Color: engine.BlackColor}
+	}
+	return engine.Player{Color: engine.WhiteColor}
+}
+
+// Save writes game to path, in PGN if path ends with .pgn, in FEN otherwise
+func Save(game engine.Game, path string) error {
+	content := game.FEN() + "\n"
+	if strings.EqualFold(filepath.Ext(path), ".pgn") {
+		content = game.PGN(nil)
+	}
+	return ioutil.WriteFile(path, []byte(content), 0644)
+}
+
+// Load reads the game saved in path, the first one if the PGN file has several
+func Load(path string) (engine.Game, error) {
+	if strings.EqualFold(filepath.Ext(path), ".pgn") {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		games, err := engine.ParsePGNWithBoard(f, engine.LoadBitboard)
+		if err != nil {
+			return nil, err
+		}
+		if len(games) == 0 {
+			return nil, fmt.Errorf("no games found in %s", path)
+		}
+		return games[0].Game, nil
+	}
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return engine.ParseFENWithBoard(strings.TrimSpace(string(content)), engine.LoadBitboard)
+}
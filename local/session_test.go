@@ -0,0 +1,81 @@
+package local
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dumbogo/chess/engine"
+	"github.com/stretchr/testify/assert"
+)
+
+// runSession runs a Session on game reading the script lines, returns the session and its output
+func runSession(t *testing.T, game engine.Game, options Options, script ...string) (*Session, string) {
+	var out bytes.Buffer
+	session := NewSession(game, options, strings.NewReader(strings.Join(script, "\n")+"\n"), &out)
+	assert.NoError(t, session.Run())
+	return session, out.String()
+}
+
+func TestSession(t *testing.T) {
+	assert := assert.New(t)
+
+	// Case: two players, SAN and long algebraic moves, undo and errors
+	session, out := runSession(t, NewGame(Options{}), Options{}, "moves", "Nf6", "undo", "e4", "e7e5", "Nf3", "undo", "quit", "d4")
+	assert.Contains(out, "Nc3 Na3 Nh3 Nf3 a3 a4 b3 b4 c3 c4 d3 d4 e3 e4 f3 f4 g3 g4 h3 h4\n")
+	assert.Contains(out, "Error: illegal SAN move \"Nf6\"\n")
+	assert.Contains(out, "Error: no moves to undo\n")
+	assert.Contains(out, "Last move: e5\n")
+	assert.Contains(out, "Last move: Nf3\n")
+	assert.Equal("rnbqkbnr/pppp1ppp/8/4p3/4P3/8/PPPP1PPP/RNBQKBNR w KQkq e6 0 2", session.game.FEN())
+
+	// Case: game finished
+	session, out = runSession(t, NewGame(Options{}), Options{}, "f3", "e5", "g4", "Qh4", "a3")
+	assert.Contains(out, "Game finished, black wins by checkmate\n")
+	assert.Contains(out, "Error: game finished, black wins by checkmate\n")
+	assert.Len(session.game.Movements(), 4)
+
+	// Case: computer replies and undo takes back both moves
+	options := Options{Computer: engine.BlackColor, Level: 1}
+	session, _ = runSession(t, NewGame(options), options, "e4")
+	assert.Len(session.game.Movements(), 2)
+	assert.Equal("Computer", session.game.Movements()[1].Player.Name)
+	session, _ = runSession(t, NewGame(options), options, "e4", "undo")
+	assert.Len(session.game.Movements(), 0)
+
+	// Case: computer playing white moves first, and again after undo
+	options = Options{Computer: engine.WhiteColor, Level: 1}
+	session, _ = runSession(t, NewGame(options), options)
+	assert.Len(session.game.Movements(), 1)
+	session, out = runSession(t, NewGame(options), options, "undo", "undo")
+	assert.Len(session.game.Movements(), 1)
+	assert.NotContains(out, "Error")
+}
+
+func TestSaveLoad(t *testing.T) {
+	assert := assert.New(t)
+	dir, err := ioutil.TempDir("", "local")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+	pgn, fen := filepath.Join(dir, "game.pgn"), filepath.Join(dir, "game.fen")
+
+	session, out := runSession(t, NewGame(Options{}), Options{}, "e4", "c5", "save "+pgn, "save "+fen, "save", "load "+filepath.Join(dir, "missing.pgn"))
+	assert.Contains(out, "Saved "+pgn+"\n")
+	assert.Contains(out, "Error: usage: save <file>\n")
+	assert.Contains(out, "Error: open "+filepath.Join(dir, "missing.pgn"))
+
+	game, err := Load(pgn)
+	assert.NoError(err)
+	assert.Equal(session.game.FEN(), game.FEN())
+	assert.Len(game.Movements(), 2)
+	game, err = Load(fen)
+	assert.NoError(err)
+	assert.Equal(session.game.FEN(), game.FEN())
+
+	// Case: loading within the session
+	session, _ = runSession(t, NewGame(Options{}), Options{}, "load "+pgn, "Nf3")
+	assert.Equal("rnbqkbnr/pp1ppppp/8/2p5/4P3/5N2/PPPP1PPP/RNBQKB1R b KQkq - 1 2", session.game.FEN())
+}
@@ -0,0 +1,290 @@
+package uci
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dumbogo/chess/engine"
+	"github.com/dumbogo/chess/engine/search"
+)
+
+// stopTimeout time given to an engine to answer stop or quit before it is killed
+var stopTimeout = 2 * time.Second
+
+// ErrEngineExited the engine process exited
+var ErrEngineExited = errors.New("uci engine exited")
+
+// Client drives an external UCI engine process, one search at a time
+type Client struct {
+	// Name engine name sent on the handshake
+	Name string
+
+	cmd   *exec.Cmd
+	stdin io.WriteCloser
+	// lines engine output, closed when the process exits
+	lines chan string
+	// mu serializes commands
+	mu sync.Mutex
+	// killed true once the process was killed
+	killed bool
+}
+
+// Start launches the engine at path and completes the UCI handshake, the engine is killed
+// if the handshake does not finish before ctx is done
+func Start(ctx context.Context, path string, args ...string) (*Client, error) {
+	cmd := exec.Command(path, args...)
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	c := &Client{cmd: cmd, stdin: stdin, lines: make(chan string, 64)}
+	go func() {
+		scanner := bufio.NewScanner(stdout)
+		for scanner.Scan() {
+			c.lines <- scanner.Text()
+		}
+		close(c.lines)
+	}()
+
+	if err := c.send("uci"); err != nil {
+		c.kill()
+		return nil, err
+	}
+	err = c.readUntil(ctx, func(line string) bool {
+		if strings.HasPrefix(line, "id name ") {
+			c.Name = strings.TrimPrefix(line, "id name ")
+		}
+		return line == "uciok"
+	})
+	if err == nil {
+		err = c.ready(ctx)
+	}
+	if err != nil {
+		c.kill()
+		return nil, fmt.Errorf("uci handshake: %w", err)
+	}
+	return c, nil
+}
+
+// SetOption sets an engine option, i.e. SetOption(ctx, "Hash", "64")
+func (c *Client) SetOption(ctx context.Context, name, value string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.send(fmt.Sprintf("setoption name %s value %s", name, value)); err != nil {
+		return err
+	}
+	return c.ready(ctx)
+}
+
+// NewGame tells the engine the next search is from a different game
+func (c *Client) NewGame(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.send("ucinewgame"); err != nil {
+		return err
+	}
+	return c.ready(ctx)
+}
+
+// Search sends the initial position of game and its movements, so the engine is aware of repetitions,
+// and returns the engine best move, along with the score and principal variation of the last info line.
+// Searches without limits run until ctx is done, when the engine is asked to stop and killed if it does not answer
+func (c *Client) Search(ctx context.Context, game engine.Game, limits search.Limits) (search.Result, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	start := time.Now()
+	goCommand := "go"
+	if limits.Depth > 0 {
+		goCommand += fmt.Sprintf(" depth %d", limits.Depth)
+	}
+	if limits.Nodes > 0 {
+		goCommand += fmt.Sprintf(" nodes %d", limits.Nodes)
+	}
+	if limits.Time > 0 {
+		goCommand += fmt.Sprintf(" movetime %d", limits.Time.Milliseconds())
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, limits.Time+stopTimeout)
+		defer cancel()
+	}
+	if goCommand == "go" {
+		goCommand += " infinite"
+	}
+	position := "position fen " + game.InitialFEN()
+	if movements := game.Movements(); len(movements) > 0 {
+		position += " moves"
+		for _, m := range movements {
+			position += " " + m.LegalMove().String()
+		}
+	}
+	if err := c.send(position); err != nil {
+		return search.Result{}, err
+	}
+	if err := c.send(goCommand); err != nil {
+		return search.Result{}, err
+	}
+
+	var info []string
+	var bestMove string
+	readBestMove := func(line string) bool {
+		fields := strings.Fields(line)
+		switch {
+		case len(fields) > 1 && fields[0] == "bestmove":
+			bestMove = fields[1]
+			return true
+		case len(fields) > 0 && fields[0] == "info" && strings.Contains(line, " score "):
+			info = fields
+		}
+		return false
+	}
+	err := c.readUntil(ctx, readBestMove)
+	if err != nil && err != ErrEngineExited {
+		// stopped by ctx, the engine must answer with its best move
+		stopCtx, cancel := context.WithTimeout(context.Background(), stopTimeout)
+		defer cancel()
+		if c.send("stop") == nil {
+			err = c.readUntil(stopCtx, readBestMove)
+		}
+	}
+	if err != nil {
+		c.kill()
+		return search.Result{}, err
+	}
+
+	result, pv := parseInfo(info)
+	result.Move, err = ParseMove(game, bestMove)
+	if err != nil {
+		return search.Result{}, fmt.Errorf("uci engine best move: %w", err)
+	}
+	if result.PV = pvMoves(game, pv); len(result.PV) == 0 || result.PV[0] != result.Move {
+		result.PV = []engine.LegalMove{result.Move}
+	}
+	result.Elapsed = time.Since(start)
+	return result, nil
+}
+
+// Close quits the engine, killing it if it does not exit in time
+func (c *Client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.killed {
+		return nil
+	}
+	c.send("quit")
+	ctx, cancel := context.WithTimeout(context.Background(), stopTimeout)
+	defer cancel()
+	if err := c.readUntil(ctx, func(string) bool { return false }); err != ErrEngineExited {
+		c.kill()
+		return nil
+	}
+	return c.cmd.Wait()
+}
+
+// ready waits for the engine to process the commands sent
+func (c *Client) ready(ctx context.Context) error {
+	if err := c.send("isready"); err != nil {
+		return err
+	}
+	return c.readUntil(ctx, func(line string) bool { return line == "readyok" })
+}
+
+func (c *Client) send(command string) error {
+	if c.killed {
+		return ErrEngineExited
+	}
+	_, err := io.WriteString(c.stdin, command+"\n")
+	return err
+}
+
+// readUntil reads engine output until done returns true for a line
+func (c *Client) readUntil(ctx context.Context, done func(line string) bool) error {
+	for {
+		select {
+		case line, ok := <-c.lines:
+			if !ok {
+				return ErrEngineExited
+			}
+			if done(line) {
+				return nil
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// kill kills the engine process, discarding its output left
+func (c *Client) kill() {
+	if c.killed {
+		return
+	}
+	c.killed = true
+	c.cmd.Process.Kill()
+	go func() {
+		for range c.lines {
+		}
+	}()
+	c.cmd.Wait()
+}
+
+// parseInfo returns depth, score and nodes of an info line, along with its principal variation.
+// Mate scores are converted to search.MateScore minus the plies to mate
+func parseInfo(info []string) (search.Result, []string) {
+	var result search.Result
+	for i := 1; i < len(info)-1; i++ {
+		value, _ := strconv.Atoi(info[i+1])
+		switch info[i] {
+		case "depth":
+			result.Depth = value
+		case "nodes":
+			result.Nodes = uint64(value)
+		case "score":
+			if i+2 < len(info) {
+				value, _ = strconv.Atoi(info[i+2])
+			}
+			switch {
+			case info[i+1] == "cp":
+				result.Score = value
+			case info[i+1] == "mate" && value > 0:
+				result.Score = search.MateScore - (2*value - 1)
+			case info[i+1] == "mate":
+				result.Score = -search.MateScore - 2*value
+			}
+		case "pv":
+			return result, info[i+1:]
+		}
+	}
+	return result, nil
+}
+
+// pvMoves returns the legal moves of a principal variation starting in game position,
+// stopping on the first illegal move
+func pvMoves(game engine.Game, pv []string) []engine.LegalMove {
+	moves := []engine.LegalMove{}
+	for _, move := range pv {
+		m, err := ParseMove(game, move)
+		if err != nil {
+			break
+		}
+		game.Play(m)
+		moves = append(moves, m)
+	}
+	if len(moves) > 0 {
+		game.Rollback(len(moves))
+	}
+	return moves
+}
@@ -0,0 +1,176 @@
+package uci
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dumbogo/chess/engine"
+	"github.com/dumbogo/chess/engine/search"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeEngineEnv environment variable making the test binary run as a UCI engine
+const fakeEngineEnv = "UCI_FAKE_ENGINE"
+
+func TestMain(m *testing.M) {
+	if mode := os.Getenv(fakeEngineEnv); mode != "" {
+		fakeEngine(mode)
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// fakeEngine runs a UCI engine, "server" is the Server, "echo" answers the position received as best move,
+// other modes misbehave after the handshake
+func fakeEngine(mode string) {
+	if mode == "server" {
+		NewServer(os.Stdin, os.Stdout).Run()
+		return
+	}
+	var position string
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		switch command := strings.Fields(scanner.Text())[0]; {
+		case command == "position":
+			position = scanner.Text()
+		case command == "go" && mode == "echo":
+			fmt.Println("bestmove " + strings.ReplaceAll(position, " ", "_"))
+		case command == "uci" && mode != "silent":
+			fmt.Println("id name fake")
+			fmt.Println("uciok")
+		case command == "isready":
+			fmt.Println("readyok")
+		case command == "go" && mode == "crash":
+			os.Exit(1)
+		case command == "go" && mode == "illegal":
+			fmt.Println("info depth 1 score mate -3 nodes 10 pv e2e5")
+			fmt.Println("bestmove e2e5")
+		case command == "go" && mode == "mate":
+			fmt.Println("info string thinking")
+			fmt.Println("info depth 3 seldepth 5 score mate 2 nodes 300 pv d5f6 g7f6 c4f7 e2e5")
+			fmt.Println("bestmove d5f6 ponder g7f6")
+		case command == "quit" && mode != "hang":
+			return
+		}
+	}
+}
+
+func startFakeEngine(mode string) (*Client, error) {
+	os.Setenv(fakeEngineEnv, mode)
+	defer os.Unsetenv(fakeEngineEnv)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return Start(ctx, os.Args[0], "-test.run=TestMain")
+}
+
+func TestClient(t *testing.T) {
+	assert := assert.New(t)
+	stopTimeout = 200 * time.Millisecond
+
+	// Case: the Server as engine
+	client, err := startFakeEngine("server")
+	assert.NoError(err)
+	assert.True(strings.HasPrefix(client.Name, "chessengine"))
+	assert.NoError(client.SetOption(context.Background(), "Hash", "1"))
+	assert.NoError(client.NewGame(context.Background()))
+	fen := "6k1/5ppp/8/8/8/8/8/R5K1 w - - 0 1"
+	game, _ := engine.ParseFEN(fen)
+	result, err := client.Search(context.Background(), game, search.Limits{Depth: 3})
+	assert.NoError(err)
+	assert.Equal(engine.LegalMove{From: engine.A1, To: engine.A8}, result.Move)
+	assert.Equal(1, result.Mate())
+	assert.Equal([]engine.LegalMove{result.Move}, result.PV)
+	assert.Equal(fen, game.FEN())
+
+	game, _ = engine.ParseFEN(engine.StartingFEN)
+	result, err = client.Search(context.Background(), game, search.Limits{Time: 100 * time.Millisecond})
+	assert.NoError(err)
+	assert.Contains(game.LegalMoves(), result.Move)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	result, err = client.Search(ctx, game, search.Limits{})
+	cancel()
+	assert.NoError(err)
+	assert.Contains(game.LegalMoves(), result.Move)
+	assert.NoError(client.Close())
+
+	// Case: info parsed and principal variation stops on the first illegal move
+	client, err = startFakeEngine("mate")
+	assert.NoError(err)
+	assert.Equal("fake", client.Name)
+	fen = "r2qkb1r/pp2nppp/3p4/2pNN1B1/2BnP3/3P4/PPP2PPP/R2bK2R w KQkq - 1 10"
+	game, _ = engine.ParseFEN(fen)
+	result, err = client.Search(context.Background(), game, search.Limits{Depth: 3})
+	assert.NoError(err)
+	assert.Equal(search.Result{
+		Move:    engine.LegalMove{From: engine.D5, To: engine.F6},
+		PV:      []engine.LegalMove{{From: engine.D5, To: engine.F6}, {From: engine.G7, To: engine.F6}, {From: engine.C4, To: engine.F7}},
+		Score:   search.MateScore - 3,
+		Depth:   3,
+		Nodes:   300,
+		Elapsed: result.Elapsed,
+	}, result)
+	assert.Equal(2, result.Mate())
+	assert.Equal(fen, game.FEN())
+	assert.NoError(client.Close())
+
+	// Case: the initial position and the movements are sent, echoed lowercase in the illegal move error
+	client, err = startFakeEngine("echo")
+	assert.NoError(err)
+	game, _ = engine.ParseFEN(engine.StartingFEN)
+	_, err = client.Search(context.Background(), game, search.Limits{Depth: 1})
+	assert.EqualError(err, "uci engine best move: illegal move \"position_fen_rnbqkbnr/pppppppp/8/8/8/8/pppppppp/rnbqkbnr_w_kqkq_-_0_1\"")
+	for _, m := range []engine.LegalMove{{From: engine.G1, To: engine.F3}, {From: engine.G8, To: engine.F6}, {From: engine.F3, To: engine.G1}} {
+		game.Move(game.Turn(), m.From, m.To, m.Promotion)
+	}
+	_, err = client.Search(context.Background(), game, search.Limits{Depth: 1})
+	assert.EqualError(err, "uci engine best move: illegal move \"position_fen_rnbqkbnr/pppppppp/8/8/8/8/pppppppp/rnbqkbnr_w_kqkq_-_0_1_moves_g1f3_g8f6_f3g1\"")
+	assert.Equal("rnbqkb1r/pppppppp/5n2/8/8/8/PPPPPPPP/RNBQKBNR b KQkq - 3 2", game.FEN())
+	assert.NoError(client.Close())
+
+	// Case: illegal best move
+	client, err = startFakeEngine("illegal")
+	assert.NoError(err)
+	game, _ = engine.ParseFEN(fen)
+	_, err = client.Search(context.Background(), game, search.Limits{Depth: 1})
+	assert.EqualError(err, "uci engine best move: illegal move \"e2e5\"")
+	assert.NoError(client.Close())
+
+	// Case: engine crashes searching
+	client, err = startFakeEngine("crash")
+	assert.NoError(err)
+	_, err = client.Search(context.Background(), game, search.Limits{Depth: 1})
+	assert.Equal(ErrEngineExited, err)
+	assert.NoError(client.Close())
+
+	// Case: engine does not answer stop nor quit
+	client, err = startFakeEngine("hang")
+	assert.NoError(err)
+	_, err = client.Search(context.Background(), game, search.Limits{Time: 50 * time.Millisecond})
+	assert.Equal(context.DeadlineExceeded, err)
+	_, err = client.Search(context.Background(), game, search.Limits{Depth: 1})
+	assert.Equal(ErrEngineExited, err)
+	assert.NoError(client.Close())
+
+	client, err = startFakeEngine("hang")
+	assert.NoError(err)
+	start := time.Now()
+	assert.NoError(client.Close())
+	assert.Less(int64(time.Since(start)), int64(time.Second))
+
+	// Case: handshake timeout
+	os.Setenv(fakeEngineEnv, "silent")
+	ctx, cancel = context.WithTimeout(context.Background(), 100*time.Millisecond)
+	_, err = Start(ctx, os.Args[0], "-test.run=TestMain")
+	cancel()
+	os.Unsetenv(fakeEngineEnv)
+	assert.EqualError(err, "uci handshake: context deadline exceeded")
+
+	// Case: engine not found
+	_, err = Start(context.Background(), "/nonexistent/engine")
+	assert.Error(err)
+}
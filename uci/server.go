@@ -0,0 +1,292 @@
+// Package uci implements the Universal Chess Interface protocol, used by chess GUIs and
+// tournament managers to talk to chess engines
+package uci
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dumbogo/chess/engine"
+	"github.com/dumbogo/chess/engine/search"
+	"github.com/dumbogo/chess/version"
+)
+
+const (
+	// maxHashSize greatest Hash option accepted, in megabytes
+	maxHashSize = 1024
+	// defaultMovesToGo moves expected until the next time control when the GUI does not send movestogo
+	defaultMovesToGo = 30
+	// moveOverhead time kept for communication delays on each move
+	moveOverhead = 50 * time.Millisecond
+)
+
+// Server answers UCI commands with the engine search
+type Server struct {
+	in  io.Reader
+	out io.Writer
+	// mu protects out, written by the search while commands are read
+	mu sync.Mutex
+
+	game     engine.Game
+	searcher *search.Searcher
+	// cancel stops the search running, nil if none
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewServer creates a Server reading commands from in and writing responses to out
+func NewServer(in io.Reader, out io.Writer) *Server {
+	game, _ := engine.ParseFENWithBoard(engine.StartingFEN, engine.LoadBitboard)
+	return &Server{
+		in:       in,
+		out:      out,
+		game:     game,
+		searcher: search.New(search.DefaultHashSize),
+	}
+}
+
+// Run reads commands until quit or the end of the input, waiting for the search running on the latter
+func (s *Server) Run() error {
+	scanner := bufio.NewScanner(s.in)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		switch command, args := fields[0], fields[1:]; command {
+		case "uci":
+			s.println("id name chessengine " + version.Version)
+			s.println("id author dumbogo")
+			s.println(fmt.Sprintf("option name Hash type spin default %d min 1 max %d", search.DefaultHashSize, maxHashSize))
+			s.println("uciok")
+		case "isready":
+			s.println("readyok")
+		case "ucinewgame":
+			s.stop()
+			s.searcher.Clear()
+			s.game, _ = engine.ParseFENWithBoard(engine.StartingFEN, engine.LoadBitboard)
+		case "setoption":
+			s.stop()
+			s.setOption(args)
+		case "position":
+			s.stop()
+			if err := s.position(args); err != nil {
+				s.println("info string " + err.Error())
+			}
+		case "go":
+			s.stop()
+			s.goSearch(args)
+		case "stop":
+			s.stop()
+		case "quit":
+			s.stop()
+			return nil
+		case "debug", "register", "ponderhit":
+			// not supported, ignored as the protocol requires
+		default:
+			s.println("info string unknown command " + command)
+		}
+	}
+	// the GUI is gone, infinite searches would wait for a stop forever
+	s.stop()
+	return scanner.Err()
+}
+
+// setOption handles "setoption name <id> value <x>"
+func (s *Server) setOption(args []string) {
+	if len(args) != 4 || args[0] != "name" || args[2] != "value" {
+		s.println("info string invalid setoption " + strings.Join(args, " "))
+		return
+	}
+	switch args[1] {
+	case "Hash":
+		size, err := strconv.Atoi(args[3])
+		if err != nil || size < 1 || size > maxHashSize {
+			s.println("info string invalid Hash value " + args[3])
+			return
+		}
+		s.searcher = search.New(size)
+	default:
+		s.println("info string unknown option " + args[1])
+	}
+}
+
+// position handles "position startpos|fen <fen> [moves <move>...]", moves in long algebraic notation
+func (s *Server) position(args []string) error {
+	moves := len(args)
+	for i, arg := range args {
+		if arg == "moves" {
+			moves = i
+			break
+		}
+	}
+	var fen string
+	switch {
+	case len(args) > 0 && args[0] == "startpos":
+		fen = engine.StartingFEN
+	case len(args) > 0 && args[0] == "fen":
+		fen = strings.Join(args[1:moves], " ")
+	default:
+		return fmt.Errorf("invalid position %q", strings.Join(args, " "))
+	}
+	game, err := engine.ParseFENWithBoard(fen, engine.LoadBitboard)
+	if err != nil {
+		return err
+	}
+	if moves < len(args) {
+		for _, arg := range args[moves+1:] {
+			m, err := ParseMove(game, arg)
+			if err != nil {
+				return err
+			}
+			game.Play(m)
+		}
+	}
+	s.game = game
+	return nil
+}
+
+// goSearch handles "go", starting a search which prints info lines and the best move
+func (s *Server) goSearch(args []string) {
+	limits, infinite := goLimits(args, s.game.Turn().Color)
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel, s.done = cancel, make(chan struct{})
+	s.searcher.OnIteration = func(r search.Result) {
+		s.println(infoLine(r))
+	}
+	go func(game engine.Game, searcher *search.Searcher, done chan struct{}) {
+		defer close(done)
+		result, err := searcher.Search(ctx, game, limits)
+		if infinite {
+			// bestmove must wait for stop
+			<-ctx.Done()
+		}
+		if err != nil {
+			s.println("info string " + err.Error())
+			s.println("bestmove 0000")
+			return
+		}
+		bestMove := "bestmove " + result.Move.String()
+		if len(result.PV) > 1 {
+			bestMove += " ponder " + result.PV[1].String()
+		}
+		s.println(bestMove)
+	}(s.game, s.searcher, s.done)
+}
+
+// stop stops the search running and waits for its best move
+func (s *Server) stop() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.wait()
+}
+
+// wait waits for the search running to finish
+func (s *Server) wait() {
+	if s.done != nil {
+		<-s.done
+		s.cancel()
+		s.cancel, s.done = nil, nil
+	}
+}
+
+func (s *Server) println(line string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fmt.Fprintln(s.out, line)
+}
+
+// goLimits returns the search limits of "go" arguments for the player of color,
+// and true if the search is infinite
+func goLimits(args []string, color engine.Color) (search.Limits, bool) {
+	var limits search.Limits
+	var infinite bool
+	var timeLeft, increment time.Duration
+	movesToGo := defaultMovesToGo
+	for i := 0; i < len(args); i++ {
+		var value int
+		if i+1 < len(args) {
+			value, _ = strconv.Atoi(args[i+1])
+		}
+		switch args[i] {
+		case "infinite":
+			infinite = true
+			continue
+		case "depth":
+			limits.Depth = value
+		case "nodes":
+			limits.Nodes = uint64(value)
+		case "movetime":
+			limits.Time = time.Duration(value) * time.Millisecond
+		case "wtime":
+			if color == engine.WhiteColor {
+				timeLeft = time.Duration(value) * time.Millisecond
+			}
+		case "btime":
+			if color == engine.BlackColor {
+				timeLeft = time.Duration(value) * time.Millisecond
+			}
+		case "winc":
+			if color == engine.WhiteColor {
+				increment = time.Duration(value) * time.Millisecond
+			}
+		case "binc":
+			if color == engine.BlackColor {
+				increment = time.Duration(value) * time.Millisecond
+			}
+		case "movestogo":
+			if value > 0 {
+				movesToGo = value
+			}
+		default:
+			continue
+		}
+		i++
+	}
+	if limits.Time == 0 && timeLeft > 0 {
+		limits.Time = timeLeft/time.Duration(movesToGo) + increment*3/4
+		if max := timeLeft - moveOverhead; limits.Time > max {
+			limits.Time = max
+		}
+		if limits.Time < time.Millisecond {
+			limits.Time = time.Millisecond
+		}
+	}
+	return limits, infinite
+}
+
+// infoLine returns the info line of a search iteration
+func infoLine(r search.Result) string {
+	score := fmt.Sprintf("cp %d", r.Score)
+	if mate := r.Mate(); mate != 0 {
+		score = fmt.Sprintf("mate %d", mate)
+	}
+	ms := r.Elapsed.Milliseconds()
+	nps := uint64(0)
+	if ms > 0 {
+		nps = r.Nodes * 1000 / uint64(ms)
+	}
+	pv := make([]string, len(r.PV))
+	for i, m := range r.PV {
+		pv[i] = m.String()
+	}
+	return fmt.Sprintf("info depth %d score %s nodes %d nps %d time %d pv %s", r.Depth, score, r.Nodes, nps, ms, strings.Join(pv, " "))
+}
+
+// ParseMove returns the legal move of game in long algebraic notation, i.e. "e2e4", "e7e8q"
+func ParseMove(game engine.Game, move string) (engine.LegalMove, error) {
+	move = strings.ToLower(move)
+	for _, m := range game.LegalMoves() {
+		if m.String() == move {
+			return m, nil
+		}
+	}
+	return engine.LegalMove{}, fmt.Errorf("illegal move %q", move)
+}
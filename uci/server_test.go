@@ -0,0 +1,115 @@
+package uci
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dumbogo/chess/engine"
+	"github.com/stretchr/testify/assert"
+)
+
+// runScript runs a Server reading the script lines, returns the lines written
+func runScript(t *testing.T, script ...string) []string {
+	var out bytes.Buffer
+	err := NewServer(strings.NewReader(strings.Join(script, "\n")+"\n"), &out).Run()
+	assert.NoError(t, err)
+	return strings.Split(strings.TrimSpace(out.String()), "\n")
+}
+
+// bestMove returns the move of the last bestmove line
+func bestMove(lines []string) string {
+	for i := len(lines) - 1; i >= 0; i-- {
+		if fields := strings.Fields(lines[i]); len(fields) > 1 && fields[0] == "bestmove" {
+			return fields[1]
+		}
+	}
+	return ""
+}
+
+func TestServer(t *testing.T) {
+	assert := assert.New(t)
+
+	// Case: handshake
+	lines := runScript(t, "uci", "isready", "quit")
+	assert.True(strings.HasPrefix(lines[0], "id name chessengine"))
+	assert.Contains(lines, "option name Hash type spin default 16 min 1 max 1024")
+	assert.Equal([]string{"uciok", "readyok"}, lines[len(lines)-2:])
+
+	// Case: mate in one from a FEN position with moves
+	lines = runScript(t,
+		"ucinewgame",
+		"position fen 6k1/5ppp/8/8/8/8/8/R5K1 w - - 0 1 moves a1b1 g8h8 b1a1 h8g8",
+		"go depth 3",
+	)
+	assert.Equal("a1a8", bestMove(lines))
+	assert.Contains(lines[len(lines)-2], "score mate 1")
+	assert.Contains(lines[len(lines)-2], "pv a1a8")
+
+	// Case: search from the starting position with moves, limited by depth, nodes and clocks
+	for _, command := range []string{"go depth 2", "go nodes 3000", "go movetime 100", "go wtime 1000 btime 1000 winc 10 binc 10", "go wtime 60000 btime 60000 movestogo 40"} {
+		lines = runScript(t, "setoption name Hash value 1", "position startpos moves e2e4 e7e5 g1f3", command)
+		game, _ := engine.ParseFEN("rnbqkbnr/pppp1ppp/8/4p3/4P3/5N2/PPPP1PPP/RNBQKB1R b KQkq - 1 2")
+		_, err := ParseMove(game, bestMove(lines))
+		assert.NoError(err, command)
+		assert.True(strings.HasPrefix(lines[0], "info depth 1 score cp "), command)
+	}
+
+	// Case: infinite search until stop
+	start := time.Now()
+	lines = runScript(t, "position startpos", "go infinite", "isready", "stop", "quit")
+	assert.Contains(lines, "readyok")
+	assert.NotEmpty(bestMove(lines))
+	assert.Less(int64(time.Since(start)), int64(5*time.Second))
+
+	// Case: input ends while searching infinitely
+	done := make(chan []string)
+	go func() {
+		done <- runScript(t, "position startpos", "go infinite")
+	}()
+	select {
+	case lines = <-done:
+		assert.NotEmpty(bestMove(lines))
+	case <-time.After(5 * time.Second):
+		t.Fatal("search not stopped at the end of the input")
+	}
+
+	// Case: finished game, invalid commands
+	lines = runScript(t,
+		"position fen R5k1/5ppp/8/8/8/8/8/6K1 b - - 1 1",
+		"go depth 1",
+		"position startpos moves e2e5",
+		"position fen 8/8/8 w - - 0 1",
+		"setoption name Hash value 0",
+		"setoption name Threads value 4",
+		"foo",
+	)
+	assert.Equal([]string{
+		"info string game finished, white wins by checkmate",
+		"bestmove 0000",
+		"info string illegal move \"e2e5\"",
+	}, lines[:3])
+	assert.True(strings.HasPrefix(lines[3], "info string invalid FEN"))
+	assert.Equal([]string{
+		"info string invalid Hash value 0",
+		"info string unknown option Threads",
+		"info string unknown command foo",
+	}, lines[4:])
+}
+
+func TestGoLimits(t *testing.T) {
+	assert := assert.New(t)
+	limits, infinite := goLimits(strings.Fields("wtime 30000 btime 6000 winc 1000 binc 200"), engine.BlackColor)
+	assert.False(infinite)
+	assert.Equal(200*time.Millisecond+150*time.Millisecond, limits.Time)
+
+	limits, _ = goLimits(strings.Fields("wtime 30000 btime 6000 winc 1000 binc 200 movestogo 1"), engine.WhiteColor)
+	assert.Equal(30*time.Second-moveOverhead, limits.Time)
+
+	limits, infinite = goLimits(strings.Fields("infinite depth 5 nodes 100"), engine.WhiteColor)
+	assert.True(infinite)
+	assert.Equal(5, limits.Depth)
+	assert.Equal(uint64(100), limits.Nodes)
+	assert.Zero(limits.Time)
+}